@@ -0,0 +1,54 @@
+// Package buildinfo berisi metadata build (versi, commit, waktu build) yang diisi saat link
+// dengan -ldflags, misalnya:
+//
+//	go build -ldflags "\
+//	  -X github.com/rakafajars/go-manajemen-project/buildinfo.Version=v1.2.0 \
+//	  -X github.com/rakafajars/go-manajemen-project/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/rakafajars/go-manajemen-project/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" \
+//	  ./cmd/server
+//
+// Nilainya ditampilkan oleh endpoint GET /version.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Variabel di bawah ini sengaja bertipe string biasa (bukan const) karena -ldflags -X
+// hanya bisa mengisi variabel string.
+var (
+	Version   = "dev" // Versi rilis, misal "v1.2.0"
+	Commit    = ""    // Hash commit git
+	BuildTime = ""    // Waktu build (RFC 3339, UTC)
+)
+
+// Info adalah metadata build yang dikembalikan oleh GET /version.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get mengembalikan metadata build.
+//
+// Jika Commit tidak diisi lewat -ldflags, nilainya diambil dari informasi VCS yang otomatis
+// disematkan oleh "go build" (vcs.revision), jika ada.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok && info.Commit == "" {
+		for _, setting := range build.Settings {
+			if setting.Key == "vcs.revision" {
+				info.Commit = setting.Value
+			}
+		}
+	}
+	return info
+}
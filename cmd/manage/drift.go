@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/databases"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/urfave/cli/v2"
+)
+
+// driftCommand membuat perintah "drift" yang membandingkan model GORM di package models
+// dengan skema database yang sedang berjalan. Exit code 1 jika ada perbedaan,
+// sehingga bisa dipakai di CI setelah "migrate up".
+func driftCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "drift",
+		Usage: "compare GORM models with the live database schema",
+		Action: func(c *cli.Context) error {
+			drifts, err := databases.CheckDrift(c.Context, config.DB, models.All())
+			if err != nil {
+				return err
+			}
+			if len(drifts) == 0 {
+				fmt.Println("no schema drift found")
+				return nil
+			}
+
+			for _, drift := range drifts {
+				fmt.Printf("[%s] %s\n", drift.Kind, drift)
+			}
+			return cli.Exit(fmt.Sprintf("%d schema drift(s) found", len(drifts)), 1)
+		},
+	}
+}
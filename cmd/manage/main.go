@@ -0,0 +1,46 @@
+// Command manage berisi perintah-perintah administrasi di luar server HTTP,
+// misalnya menjalankan migration database, mengecek schema drift, dan mengisi data (seed).
+//
+// Cara menjalankan:
+//
+//	go run ./cmd/manage migrate up
+//	go run ./cmd/manage seed
+//	go run ./cmd/manage --help
+//
+// Konfigurasi database dibaca dari .env, sama seperti server (lihat config.LoadEnv).
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/rakafajars/go-manajemen-project/buildinfo"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	app := &cli.App{
+		Name:    "manage",
+		Usage:   "administrative commands for go-manajemen-project",
+		Version: buildinfo.Version,
+		// Konfigurasi & database disiapkan sekali sebelum subcommand mana pun dijalankan.
+		Before: func(c *cli.Context) error {
+			config.LoadEnv()
+			config.ConnectDB()
+			return nil
+		},
+		After: func(c *cli.Context) error {
+			return config.CloseDB()
+		},
+		Commands: []*cli.Command{
+			migrateCommand(),
+			driftCommand(),
+			seedCommand(),
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/databases"
+	"github.com/urfave/cli/v2"
+)
+
+// migrateCommand membuat perintah "migrate" beserta subcommand-nya:
+//
+//	migrate up [N]          menjalankan N migration berikutnya (default: semua)
+//	migrate down [N]        membatalkan N migration terakhir (default: 1)
+//	migrate down --all      membatalkan semua migration
+//	migrate status          menampilkan versi saat ini dan migration yang belum dijalankan
+//	migrate force VERSION   menyetel versi tanpa menjalankan SQL (setelah perbaikan manual)
+func migrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "apply or roll back database migrations",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "up",
+				Usage:     "apply pending migrations",
+				ArgsUsage: "[N]",
+				Action: func(c *cli.Context) error {
+					steps, err := stepsArg(c, 0)
+					if err != nil {
+						return err
+					}
+					migrator, err := newMigrator()
+					if err != nil {
+						return err
+					}
+
+					applied, err := migrator.Up(c.Context, steps)
+					for _, migration := range applied {
+						fmt.Printf("applied %d_%s\n", migration.Version, migration.Name)
+					}
+					if err != nil {
+						return err
+					}
+					if len(applied) == 0 {
+						fmt.Println("no pending migrations")
+					}
+					return nil
+				},
+			},
+			{
+				Name:      "down",
+				Usage:     "roll back the last N migrations",
+				ArgsUsage: "[N]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "all", Usage: "roll back every migration"},
+				},
+				Action: func(c *cli.Context) error {
+					steps, err := stepsArg(c, 1)
+					if err != nil {
+						return err
+					}
+					if c.Bool("all") {
+						steps = 0
+					}
+					migrator, err := newMigrator()
+					if err != nil {
+						return err
+					}
+
+					reverted, err := migrator.Down(c.Context, steps)
+					for _, migration := range reverted {
+						fmt.Printf("reverted %d_%s\n", migration.Version, migration.Name)
+					}
+					if err != nil {
+						return err
+					}
+					if len(reverted) == 0 {
+						fmt.Println("nothing to roll back")
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "status",
+				Usage: "show the current schema version and pending migrations",
+				Action: func(c *cli.Context) error {
+					migrator, err := newMigrator()
+					if err != nil {
+						return err
+					}
+
+					status, err := migrator.Status(c.Context)
+					if err != nil {
+						return err
+					}
+					fmt.Printf("current version: %d (latest %d)\n", status.Version, status.Latest)
+					if status.Dirty {
+						fmt.Println("database is DIRTY: fix it manually, then run \"migrate force VERSION\"")
+					}
+					if len(status.Pending) == 0 {
+						fmt.Println("database is up to date")
+						return nil
+					}
+					fmt.Println("pending migrations:")
+					for _, migration := range status.Pending {
+						fmt.Printf("  %d_%s\n", migration.Version, migration.Name)
+					}
+					return nil
+				},
+			},
+			{
+				Name:      "force",
+				Usage:     "set the schema version without running any SQL and clear the dirty flag",
+				ArgsUsage: "VERSION",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return cli.Exit("usage: migrate force VERSION", 2)
+					}
+					version, err := strconv.ParseUint(c.Args().First(), 10, 64)
+					if err != nil {
+						return cli.Exit(fmt.Sprintf("invalid version %q", c.Args().First()), 2)
+					}
+					migrator, err := newMigrator()
+					if err != nil {
+						return err
+					}
+
+					if err := migrator.Force(c.Context, version); err != nil {
+						return err
+					}
+					fmt.Printf("forced version %d\n", version)
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// newMigrator membuat Migrator dari pool database milik GORM.
+func newMigrator() (*databases.Migrator, error) {
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		return nil, err
+	}
+	return databases.NewMigrator(sqlDB)
+}
+
+// stepsArg membaca argumen opsional N (jumlah migration). Tanpa argumen, fallback dipakai.
+func stepsArg(c *cli.Context, fallback int) (int, error) {
+	if c.NArg() == 0 {
+		return fallback, nil
+	}
+	steps, err := strconv.Atoi(c.Args().First())
+	if err != nil || steps <= 0 {
+		return 0, cli.Exit(fmt.Sprintf("invalid N %q, must be a positive number", c.Args().First()), 2)
+	}
+	return steps, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/databases/seed"
+	"github.com/urfave/cli/v2"
+)
+
+// seedCommand membuat perintah "seed":
+//
+//	seed                     menjalankan seeder default (admin, demo); di production seeder
+//	                         dengan password yang sudah diketahui (demo) dilewati
+//	seed admin load          menjalankan seeder tertentu, urutannya mengikuti seed.All()
+//	seed load --scale 1000   dataset load testing dengan 1000 user/board
+//	seed --list              menampilkan semua seeder
+func seedCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "seed",
+		Usage:     "fill the database with initial, demo or load-testing data",
+		ArgsUsage: "[SEEDER...]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "list", Usage: "list available seeders and exit"},
+			&cli.IntFlag{Name: "scale", Value: seed.DefaultLoadScale, Usage: "number of synthetic users/boards for the \"load\" seeder"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.Bool("list") {
+				for _, seeder := range seed.All() {
+					marker := " "
+					if seeder.Default {
+						marker = "*"
+					}
+					fmt.Printf("%s %-6s %s\n", marker, seeder.Name, seeder.Description)
+				}
+				fmt.Println("(* = run when no seeder is given; seeders with known passwords are skipped in production)")
+				return nil
+			}
+
+			cfg := config.AppConfig
+			return seed.Run(c.Context, config.DB, c.Args().Slice(), seed.Options{
+				Production:    cfg.IsProduction(),
+				AdminName:     cfg.SeedAdminName,
+				AdminEmail:    cfg.SeedAdminEmail,
+				AdminPassword: cfg.SeedAdminPassword,
+				LoadScale:     c.Int("scale"),
+			})
+		},
+	}
+}
@@ -0,0 +1,145 @@
+// Command server adalah entrypoint HTTP API aplikasi manajemen project.
+//
+// Cara menjalankan:
+//
+//	go run ./cmd/server
+//
+// Urutan yang dilakukan:
+//  1. Membaca konfigurasi dari .env (config.LoadEnv)
+//  2. Membuka koneksi database (config.ConnectDB)
+//  3. Menjalankan goroutine background: sinkronisasi token yang dicabut, thumbnail worker,
+//     dan (jika ORDERING_STRATEGY=rank) rank rebalancer setelah rank diisi/dirapikan
+//  4. Mendaftarkan semua route ke Fiber (routes.Setup), termasuk /healthz, /readyz, /version
+//  5. Menjalankan server dan menunggu sinyal berhenti (SIGINT/SIGTERM)
+//  6. Graceful shutdown: menunggu request yang sedang berjalan dan goroutine background
+//     selesai, lalu menutup pool database
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/routes"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/storage"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// shutdownTimeout adalah batas waktu menunggu request yang masih berjalan
+// saat server diminta berhenti. Lewat dari ini, koneksi akan diputus paksa.
+const shutdownTimeout = 10 * time.Second
+
+// multipartOverhead adalah ruang tambahan di atas MAX_UPLOAD_SIZE untuk boundary
+// dan header multipart/form-data.
+const multipartOverhead = 1 << 20
+
+func main() {
+	// 1. Konfigurasi & database harus siap sebelum route didaftarkan.
+	config.LoadEnv()
+	config.ConnectDB()
+
+	// 2. Goroutine background memakai context ini, yang dibatalkan saat shutdown.
+	// Semuanya didaftarkan ke wg agar main bisa menunggu sampai benar-benar berhenti.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+
+	revocationService := startRevocationSync(ctx, &wg)
+	attachmentStorage, thumbnailWorker := startThumbnailWorker(ctx, &wg)
+	if config.AppConfig.OrderingStrategy == config.OrderingRank {
+		startRankRebalancer(ctx, &wg)
+	}
+
+	// 3. Buat aplikasi Fiber dan daftarkan semua route.
+	// BodyLimit default Fiber hanya 4 MB, jadi dinaikkan mengikuti MAX_UPLOAD_SIZE
+	// (ditambah sedikit ruang untuk header multipart). Ukuran per file dicek lagi di service.
+	// ErrorHandler mengubah error yang di-return handler (termasuk 404/405 bawaan Fiber)
+	// menjadi format utils.Response lengkap dengan kode error.
+	app := fiber.New(fiber.Config{
+		AppName:      "go-manajemen-project",
+		BodyLimit:    int(config.AppConfig.MaxUploadSize) + multipartOverhead,
+		ErrorHandler: utils.ErrorHandler,
+	})
+	routes.Setup(app, routes.Dependencies{
+		Revocations:     revocationService,
+		Storage:         attachmentStorage,
+		ThumbnailWorker: thumbnailWorker,
+	})
+
+	// 4. Jalankan server di goroutine terpisah.
+	// Kenapa goroutine? Karena app.Listen() bersifat "blocking" (tidak pernah return
+	// selama server hidup). Dengan goroutine, main() bisa lanjut menunggu sinyal OS.
+	go func() {
+		addr := fmt.Sprintf(":%d", config.AppConfig.AppPort)
+		if err := app.Listen(addr); err != nil {
+			log.Fatal("failed to start server: ", err)
+		}
+	}()
+
+	// 5. Tunggu sinyal dari OS.
+	// SIGINT  = Ctrl+C di terminal.
+	// SIGTERM = Sinyal standar dari Docker/Kubernetes saat container dihentikan.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+	log.Println("Shutting down server...")
+
+	// 6. Graceful shutdown.
+	// ShutdownWithTimeout berhenti menerima koneksi baru dan menunggu request
+	// yang sedang diproses selesai (maksimal shutdownTimeout).
+	if err := app.ShutdownWithTimeout(shutdownTimeout); err != nil {
+		log.Println("Server forced to shutdown:", err)
+	}
+
+	// Setelah tidak ada request lagi, hentikan goroutine background dan tunggu sampai
+	// semuanya return. Baru setelah itu pool database aman untuk ditutup.
+	cancel()
+	wg.Wait()
+	if err := config.CloseDB(); err != nil {
+		log.Println("Failed to close database:", err)
+	}
+
+	log.Println("Server stopped")
+}
+
+// startRankRebalancer mengisi rank yang masih kosong (misal data lama dari strategi "array")
+// SEBELUM server menerima request, lalu menjalankan rebalancer berkala di background.
+func startRankRebalancer(ctx context.Context, wg *sync.WaitGroup) {
+	rebalancer := services.NewRankRebalancer(repositories.NewRankRepository())
+	if err := rebalancer.RunOnce(); err != nil {
+		log.Fatal("failed to backfill ranks: ", err)
+	}
+	wg.Go(func() { rebalancer.Run(ctx, config.AppConfig.RankRebalanceInterval) })
+}
+
+// startRevocationSync memuat daftar token yang dicabut SEBELUM server menerima request,
+// lalu menyinkronkannya berkala di background (bukan di jalur request).
+func startRevocationSync(ctx context.Context, wg *sync.WaitGroup) services.TokenRevocationService {
+	revocationService := services.NewTokenRevocationService(repositories.NewTokenRevocationRepository())
+	if err := revocationService.Sync(); err != nil {
+		log.Fatal("failed to load token revocations: ", err)
+	}
+	wg.Go(func() { revocationService.Run(ctx, services.RevocationSyncInterval) })
+	return revocationService
+}
+
+// startThumbnailWorker membuka storage attachment (sesuai STORAGE_DRIVER) lalu menjalankan
+// thumbnail worker di background.
+func startThumbnailWorker(ctx context.Context, wg *sync.WaitGroup) (storage.Storage, services.ThumbnailWorker) {
+	attachmentStorage, err := storage.New(ctx, config.AppConfig)
+	if err != nil {
+		log.Fatal("failed to initialize attachment storage: ", err)
+	}
+	thumbnailWorker := services.NewThumbnailWorker(repositories.NewCardAttachmentRepository(), attachmentStorage)
+	wg.Go(func() { thumbnailWorker.Run(ctx, services.ThumbnailPollInterval) })
+	return attachmentStorage, thumbnailWorker
+}
@@ -1,19 +1,27 @@
 // Package config berisi konfigurasi aplikasi.
 // File ini bertanggung jawab untuk:
-// 1. Membaca environment variables dari file .env
+// 1. Membaca konfigurasi (environment variables, file .env, dan file YAML opsional)
 // 2. Menyimpan konfigurasi secara global agar bisa diakses dari mana saja
 // 3. Mengelola koneksi ke database PostgreSQL
+//
+// Cara konfigurasi dibaca dan divalidasi ada di loader.go.
 package config
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/joho/godotenv" // Library untuk membaca file .env
-	"gorm.io/driver/postgres"  // Driver PostgreSQL untuk GORM
-	"gorm.io/gorm"             // ORM (Object Relational Mapping) untuk Go
+	"github.com/jackc/pgx/v5"        // Parser DSN PostgreSQL (dipakai juga oleh driver GORM)
+	"github.com/jackc/pgx/v5/stdlib" // Adapter pgx ke *sql.DB
+	"github.com/joho/godotenv"       // Library untuk membaca file .env
+	"gorm.io/driver/postgres"        // Driver PostgreSQL untuk GORM
+	"gorm.io/gorm"                   // ORM (Object Relational Mapping) untuk Go
+	"gorm.io/plugin/dbresolver"      // Routing query ke read replica
 )
 
 // ============================================================================
@@ -26,8 +34,8 @@ var (
 	// Tipe *gorm.DB adalah pointer, artinya variabel ini menyimpan "alamat" objek DB.
 	DB *gorm.DB
 
-	// AppConfig menyimpan semua konfigurasi aplikasi yang dibaca dari .env.
-	// Pointer (*Config) agar kita bisa mengubah isinya dari fungsi loadEnv().
+	// AppConfig menyimpan semua konfigurasi aplikasi yang sudah dibaca dan divalidasi.
+	// Pointer (*Config) agar kita bisa mengubah isinya dari fungsi LoadEnv().
 	AppConfig *Config
 )
 
@@ -36,25 +44,95 @@ var (
 // ============================================================================
 // Config adalah struct (blueprint) untuk menyimpan semua konfigurasi aplikasi.
 // Ini seperti "wadah" yang mengelompokkan data yang saling berhubungan.
+//
+// Semua field sudah bertipe sesuai isinya (int, bool, time.Duration), sehingga kode lain
+// tidak perlu mem-parse string lagi. Nama env var untuk setiap field ada di komentarnya.
 type Config struct {
-	AppPort           string // Port server, misal "3000"
-	DBHost            string // Alamat database, misal "localhost"
-	DBPort            string // Port database, misal "5432"
-	DBUser            string // Username database
-	DBPassword        string // Password database
-	DBName            string // Nama database
-	JWTSecret         string // Kunci rahasia untuk menandatangani JWT token
-	JWTExpiredMinutes string // Berapa menit token expired
-	JWTRefreshToken   string // Durasi refresh token
-	JWTExpire         string // Durasi token (format: "1h", "24h", dll)
+	AppEnv     string // APP_ENV: profile "development" (default), "test", atau "production"
+	AppPort    int    // PORT: port server, misal 3000
+	DBHost     string // DB_HOST: alamat database, misal "localhost"
+	DBPort     int    // DB_PORT: port database, misal 5432
+	DBUser     string // DB_USER: username database
+	DBPassword string // DB_PASSWORD: password database
+	DBName     string // DB_NAME: nama database
+
+	// Koneksi & connection pool database.
+	DBSSLMode          string        // DB_SSLMODE: sslmode PostgreSQL, misal "disable" atau "verify-full"
+	DBMaxIdleConns     int           // DB_MAX_IDLE_CONNS: jumlah koneksi idle yang tetap dibuka
+	DBMaxOpenConns     int           // DB_MAX_OPEN_CONNS: jumlah maksimal koneksi bersamaan (0 = tanpa batas)
+	DBConnMaxLifetime  time.Duration // DB_CONN_MAX_LIFETIME: umur maksimal satu koneksi (0 = selamanya)
+	DBConnMaxIdleTime  time.Duration // DB_CONN_MAX_IDLE_TIME: lama koneksi boleh idle sebelum ditutup (0 = selamanya)
+	DBStatementTimeout time.Duration // DB_STATEMENT_TIMEOUT: statement_timeout PostgreSQL (0 = tanpa batas)
+	DBApplicationName  string        // DB_APPLICATION_NAME: application_name yang terlihat di pg_stat_activity
+
+	// DBReadReplicas (DB_READ_REPLICAS) berisi DSN read replica, dipisah koma. Jika diisi,
+	// query list/pencarian yang memakai ReadDB() dibaca dari replica, sedangkan semua
+	// penulisan (dan query lain) tetap ke primary. Kosong berarti semua ke primary.
+	DBReadReplicas []string
+
+	JWTSecret       string        // JWT_SECRET: kunci rahasia untuk menandatangani JWT token
+	JWTExpire       time.Duration // JWT_EXPIRED: durasi access token, dibuat pendek karena ada refresh token
+	RefreshTokenTTL time.Duration // REFRESH_TOKEN_EXPIRED: durasi refresh token
+
+	// OrderingStrategy (ORDERING_STRATEGY) menentukan cara urutan list & kartu disimpan:
+	//   - "array": urutan disimpan sebagai array UUID di ListPosition/CardPosition (default).
+	//   - "rank":  setiap list/kartu punya kolom rank (fractional index), memindahkan item
+	//              hanya mengubah satu baris. Lihat utils.RankBetween.
+	OrderingStrategy      string
+	RankRebalanceInterval time.Duration // RANK_REBALANCE_INTERVAL: seberapa sering rank yang terlalu panjang dirapikan
+
+	// Penyimpanan file attachment. StorageDriver (STORAGE_DRIVER) bernilai "local" (folder di disk)
+	// atau "s3" (Amazon S3 / layanan kompatibel S3 seperti MinIO).
+	StorageDriver    string
+	StorageLocalPath string // STORAGE_LOCAL_PATH: folder root untuk driver "local", misal "./uploads"
+	S3Endpoint       string // S3_ENDPOINT: host S3 tanpa skema, misal "localhost:9000" atau "s3.amazonaws.com"
+	S3Region         string // S3_REGION: region bucket, misal "us-east-1"
+	S3Bucket         string // S3_BUCKET: nama bucket
+	S3AccessKey      string // S3_ACCESS_KEY: access key ID
+	S3SecretKey      string // S3_SECRET_KEY: secret access key
+	S3UseSSL         bool   // S3_USE_SSL: true untuk https
+	MaxUploadSize    int64  // MAX_UPLOAD_SIZE: ukuran maksimal file upload dalam byte, misal 10485760 (10 MB)
+
+	// Akun admin yang dibuat oleh seeder "admin" (go run ./cmd/manage seed admin).
+	// Nilai default hanya untuk development: di production seeder menolak berjalan
+	// jika email atau password masih default.
+	SeedAdminName     string // SEED_ADMIN_NAME
+	SeedAdminEmail    string // SEED_ADMIN_EMAIL
+	SeedAdminPassword string // SEED_ADMIN_PASSWORD
 }
 
+// Nilai yang valid untuk Config.AppEnv (profile).
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvProduction  = "production"
+)
+
+// Nilai yang valid untuk Config.DBSSLMode (lihat dokumentasi sslmode PostgreSQL).
+var dbSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Nilai yang valid untuk Config.OrderingStrategy.
+const (
+	OrderingArray = "array"
+	OrderingRank  = "rank"
+)
+
+// Nilai yang valid untuk Config.StorageDriver.
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+)
+
 // ============================================================================
-// FUNGSI loadEnv
+// FUNGSI LoadEnv
 // ============================================================================
-// loadEnv membaca file .env dan mengisi variabel AppConfig.
+// LoadEnv membaca konfigurasi dan mengisi variabel AppConfig.
 // Fungsi ini harus dipanggil di awal aplikasi (biasanya di main.go atau init()).
-func loadEnv() {
+// Huruf besar di awal nama fungsi membuatnya "exported" (bisa dipanggil dari package lain).
+//
+// Jika ada nilai yang tidak valid, aplikasi langsung berhenti (fail fast) dengan pesan
+// yang menyebutkan nilai-nilai yang salah, bukan baru ketahuan saat fitur tersebut dipakai.
+func LoadEnv() {
 	// godotenv.Load() membaca file .env di root project.
 	// Isi file .env akan masuk ke environment variables sistem.
 	err := godotenv.Load()
@@ -64,74 +142,119 @@ func loadEnv() {
 		log.Println("No .env file found")
 	}
 
-	// Buat objek Config baru dan isi dengan nilai dari environment variables.
-	// &Config{...} artinya kita membuat objek lalu mengambil alamatnya (pointer).
-	AppConfig = &Config{
-		AppPort:           getEnv("PORT", "3000"),
-		DBHost:            getEnv("DB_HOST", "localhost"),
-		DBPort:            getEnv("DB_PORT", "5432"),
-		DBUser:            getEnv("DB_USER", "postgres"),
-		DBPassword:        getEnv("DB_PASSWORD", "postgres"),
-		DBName:            getEnv("DB_NAME", "project_management"),
-		JWTSecret:         getEnv("JWT_SECRET", "secret"),
-		JWTExpiredMinutes: getEnv("JWT_EXPIREY_MINUTES", "6000"),
-		JWTRefreshToken:   getEnv("REFRESH_TOKEN_EXPIRED", "24H"),
-		JWTExpire:         getEnv("JWT_EXPIRED", "1h"),
+	cfg, err := Load()
+	if err != nil {
+		log.Fatal("invalid configuration: ", err)
 	}
+	AppConfig = cfg
 }
 
-// ============================================================================
-// FUNGSI getEnv
-// ============================================================================
-// getEnv adalah helper function untuk mengambil nilai environment variable.
-// Jika key tidak ditemukan, fungsi ini mengembalikan nilai fallback (default).
-//
-// Parameter:
-//   - key: Nama environment variable yang dicari (misal: "DB_HOST")
-//   - fallback: Nilai default jika key tidak ditemukan (misal: "localhost")
-//
-// Return: Nilai environment variable atau fallback.
-func getEnv(key string, fallback string) string {
-	// os.LookupEnv mengembalikan 2 nilai:
-	// 1. value: Isi dari environment variable
-	// 2. exist: Boolean, true jika key ditemukan
-	value, exist := os.LookupEnv(key)
-	if exist {
-		return value
-	} else {
-		return fallback
-	}
+// IsProduction mengembalikan true jika aplikasi berjalan dengan APP_ENV=production.
+func (c *Config) IsProduction() bool {
+	return c.AppEnv == EnvProduction
 }
 
 // ============================================================================
-// FUNGSI connectDB
+// FUNGSI ConnectDB
 // ============================================================================
-// connectDB membuat koneksi ke database PostgreSQL menggunakan GORM.
-// Fungsi ini harus dipanggil SETELAH loadEnv() agar AppConfig sudah terisi.
-func connectDB() {
+// ConnectDB membuat koneksi ke database PostgreSQL menggunakan GORM.
+// Fungsi ini harus dipanggil SETELAH LoadEnv() agar AppConfig sudah terisi.
+func ConnectDB() {
 	cfg := AppConfig
 
 	// DSN (Data Source Name) adalah string koneksi ke database.
 	// Format: "host=... port=... user=... password=... dbname=... sslmode=..."
-	// sslmode=disable artinya tidak pakai SSL (aman untuk development lokal).
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
+	// Nilai diberi tanda kutip agar password yang berisi spasi atau kutip tetap terbaca benar.
+	// sslmode=disable (default development) artinya tidak pakai SSL; production default "require".
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		quoteDSNValue(cfg.DBHost), cfg.DBPort, quoteDSNValue(cfg.DBUser),
+		quoteDSNValue(cfg.DBPassword), quoteDSNValue(cfg.DBName), quoteDSNValue(cfg.DBSSLMode))
+
+	sqlDB, err := openPool(dsn, cfg)
+	if err != nil {
+		log.Fatal("failed to connect to database: ", err)
+	}
 
 	// gorm.Open() membuka koneksi ke database.
-	// postgres.Open(dsn) menerjemahkan DSN menjadi koneksi PostgreSQL.
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// postgres.New(...Conn...) memakai *sql.DB yang sudah kita siapkan di openPool,
+	// sehingga application_name & statement_timeout ikut terpasang di setiap koneksi.
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
 	if err != nil {
 		// log.Fatal akan mencetak error dan MENGHENTIKAN aplikasi.
 		// Karena tanpa database, aplikasi tidak bisa berjalan.
 		log.Fatal("failed to connect to database", err)
 	}
 
-	// db.DB() mengembalikan *sql.DB (koneksi database standar Go).
-	// Ini dibutuhkan untuk mengatur connection pool.
-	sqlDB, err := db.DB()
+	// ========================================================================
+	// READ REPLICA
+	// ========================================================================
+	// dbresolver mendaftarkan replica dengan nama replicaResolver. Karena tidak ada resolver
+	// global, query biasa tetap ke primary; hanya query yang memakai ReadDB() (klausa
+	// dbresolver.Use) yang dibaca dari replica. Di dalam transaksi semuanya tetap ke primary.
+	if len(cfg.DBReadReplicas) > 0 {
+		replicas := make([]gorm.Dialector, 0, len(cfg.DBReadReplicas))
+		for i, replicaDSN := range cfg.DBReadReplicas {
+			replicaDB, err := openPool(replicaDSN, cfg)
+			if err != nil {
+				log.Fatalf("failed to connect to read replica #%d: %v", i+1, err)
+			}
+			replicaPools = append(replicaPools, replicaDB)
+			replicas = append(replicas, postgres.New(postgres.Config{Conn: replicaDB}))
+		}
+
+		resolver := dbresolver.Register(dbresolver.Config{Replicas: replicas}, replicaResolver)
+		if err := db.Use(resolver); err != nil {
+			log.Fatal("failed to register read replicas: ", err)
+		}
+	}
+
+	// Simpan koneksi ke variabel global DB agar bisa dipakai di seluruh aplikasi.
+	DB = db
+}
+
+// replicaResolver adalah nama resolver dbresolver untuk read replica (lihat ReadDB).
+const replicaResolver = "replicas"
+
+// replicaPools menyimpan connection pool read replica agar bisa ditutup oleh CloseDB.
+var replicaPools []*sql.DB
+
+// ReadDB mengembalikan koneksi untuk query baca yang boleh sedikit tertinggal dari primary,
+// seperti daftar board dan pencarian kartu. Jika DB_READ_REPLICAS diisi, query dikirim ke
+// salah satu replica; jika tidak, ReadDB sama dengan DB.
+//
+// Jangan pakai ReadDB untuk membaca data yang baru saja ditulis di request yang sama
+// (read-your-writes), karena replikasi bisa terlambat beberapa saat.
+func ReadDB() *gorm.DB {
+	if len(replicaPools) == 0 {
+		return DB
+	}
+	return DB.Clauses(dbresolver.Use(replicaResolver))
+}
+
+// openPool membuka connection pool *sql.DB untuk satu DSN (primary atau replica) dengan
+// pengaturan koneksi & pool dari konfigurasi.
+func openPool(dsn string, cfg *Config) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
 	if err != nil {
-		log.Fatal("failed to get database instance", err)
+		return nil, err
+	}
+
+	// RuntimeParams dikirim saat koneksi dibuat, sama seperti "SET ..." di setiap koneksi.
+	// Nilai yang sudah ditulis langsung di DSN (misal DSN replica) tidak ditimpa.
+	setDefaultParam := func(name, value string) {
+		if _, ok := connConfig.RuntimeParams[name]; !ok {
+			connConfig.RuntimeParams[name] = value
+		}
 	}
+	if cfg.DBApplicationName != "" {
+		setDefaultParam("application_name", cfg.DBApplicationName)
+	}
+	if cfg.DBStatementTimeout > 0 {
+		// statement_timeout tanpa satuan dihitung dalam milidetik.
+		setDefaultParam("statement_timeout", strconv.FormatInt(cfg.DBStatementTimeout.Milliseconds(), 10))
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig)
 
 	// ========================================================================
 	// CONNECTION POOL SETTINGS
@@ -140,16 +263,48 @@ func connectDB() {
 
 	// MaxIdleConns: Berapa koneksi "tidur" yang tetap dibuka.
 	// Koneksi idle ini siap dipakai kapan saja tanpa perlu membuat koneksi baru.
-	sqlDB.SetMaxIdleConns(10)
+	sqlDB.SetMaxIdleConns(cfg.DBMaxIdleConns)
 
 	// MaxOpenConns: Maksimal koneksi yang boleh dibuka bersamaan.
-	// Jika sudah 100 koneksi aktif, request baru harus menunggu.
-	sqlDB.SetMaxOpenConns(100)
+	// Jika sudah penuh, request baru harus menunggu.
+	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenConns)
 
 	// ConnMaxLifetime: Berapa lama koneksi boleh hidup sebelum di-recycle.
 	// Ini mencegah koneksi "zombie" yang sudah tidak responsif.
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
 
-	// Simpan koneksi ke variabel global DB agar bisa dipakai di seluruh aplikasi.
-	DB = db
+	// ConnMaxIdleTime: Berapa lama koneksi boleh menganggur sebelum ditutup,
+	// agar koneksi sisa lonjakan traffic tidak ditahan terus.
+	sqlDB.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+
+	return sqlDB, nil
+}
+
+// quoteDSNValue memberi tanda kutip pada nilai DSN key=value, misal it's -> 'it\'s'.
+func quoteDSNValue(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// ============================================================================
+// FUNGSI CloseDB
+// ============================================================================
+// CloseDB menutup connection pool database (*sql.DB) milik GORM.
+// Dipanggil saat aplikasi berhenti (graceful shutdown) agar semua koneksi
+// dikembalikan ke PostgreSQL dengan rapi, bukan diputus paksa oleh OS.
+func CloseDB() error {
+	if DB == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, replicaDB := range replicaPools {
+		errs = append(errs, replicaDB.Close())
+	}
+	replicaPools = nil
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	return errors.Join(append(errs, sqlDB.Close())...)
 }
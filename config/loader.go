@@ -0,0 +1,368 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ============================================================================
+// SUMBER KONFIGURASI
+// ============================================================================
+// Setiap nilai konfigurasi dicari dari beberapa lapisan, yang paling atas menang:
+//  1. Environment variable (termasuk isi file .env)
+//  2. File YAML opsional yang ditunjuk env CONFIG_FILE
+//  3. Default milik profile (APP_ENV)
+//  4. Default umum (baseDefaults)
+//
+// File YAML memakai nama key yang SAMA dengan nama env var, misal:
+//
+//	APP_ENV: production
+//	PORT: 8080
+//	JWT_EXPIRED: 15m
+//	S3_USE_SSL: true
+//
+// Dengan begitu satu nama berlaku di semua tempat, dan env var selalu bisa menimpa
+// isi file (berguna untuk secret seperti JWT_SECRET yang tidak ingin ditulis di file).
+
+// configFileEnv adalah env var berisi path file YAML konfigurasi.
+const configFileEnv = "CONFIG_FILE"
+
+// baseDefaults adalah nilai default untuk semua profile.
+var baseDefaults = map[string]string{
+	"PORT":        "3000",
+	"DB_HOST":     "localhost",
+	"DB_PORT":     "5432",
+	"DB_USER":     "postgres",
+	"DB_PASSWORD": "postgres",
+	"DB_NAME":     "project_management",
+
+	"DB_SSLMODE":            "disable",
+	"DB_MAX_IDLE_CONNS":     "10",
+	"DB_MAX_OPEN_CONNS":     "100",
+	"DB_CONN_MAX_LIFETIME":  "1h",
+	"DB_CONN_MAX_IDLE_TIME": "0",
+	"DB_STATEMENT_TIMEOUT":  "0",
+	"DB_APPLICATION_NAME":   "go-manajemen-project",
+	"DB_READ_REPLICAS":      "",
+
+	"JWT_SECRET":            "secret",
+	"JWT_EXPIRED":           "15m",
+	"REFRESH_TOKEN_EXPIRED": "24h",
+
+	"ORDERING_STRATEGY":       OrderingArray,
+	"RANK_REBALANCE_INTERVAL": "10m",
+
+	"STORAGE_DRIVER":     StorageLocal,
+	"STORAGE_LOCAL_PATH": "./uploads",
+	"S3_ENDPOINT":        "localhost:9000",
+	"S3_REGION":          "us-east-1",
+	"S3_BUCKET":          "attachments",
+	"S3_ACCESS_KEY":      "",
+	"S3_SECRET_KEY":      "",
+	"S3_USE_SSL":         "false",
+	"MAX_UPLOAD_SIZE":    "10485760",
+
+	"SEED_ADMIN_NAME":     "Admin",
+	"SEED_ADMIN_EMAIL":    "admin@admin.com",
+	"SEED_ADMIN_PASSWORD": "admin",
+}
+
+// profileDefaults menimpa baseDefaults sesuai APP_ENV.
+//
+// Profile "production" sengaja mengosongkan secret: nilainya WAJIB diisi lewat env/file,
+// sehingga server tidak bisa tanpa sengaja berjalan dengan JWT_SECRET "secret".
+var profileDefaults = map[string]map[string]string{
+	EnvDevelopment: {},
+	EnvTest: {
+		"DB_NAME":            "project_management_test",
+		"STORAGE_LOCAL_PATH": "./uploads/test",
+	},
+	EnvProduction: {
+		"DB_SSLMODE":          "require",
+		"JWT_SECRET":          "",
+		"SEED_ADMIN_EMAIL":    "",
+		"SEED_ADMIN_PASSWORD": "",
+	},
+}
+
+// defaultJWTSecret adalah JWT_SECRET bawaan yang hanya boleh dipakai di luar production.
+const defaultJWTSecret = "secret"
+
+// minProductionJWTSecretLength adalah panjang minimal JWT_SECRET di production (32 byte = 256 bit,
+// sama dengan ukuran output HMAC-SHA256).
+const minProductionJWTSecretLength = 32
+
+// ============================================================================
+// FUNGSI Load
+// ============================================================================
+// Load membaca konfigurasi dari semua lapisan, mengubahnya ke tipe yang benar, lalu
+// memvalidasinya. Semua kesalahan dikumpulkan dan dikembalikan sekaligus (errors.Join).
+//
+// Berbeda dengan LoadEnv, Load tidak membaca file .env dan tidak menghentikan aplikasi,
+// sehingga bisa dipakai oleh tool lain yang ingin menangani error sendiri.
+func Load() (*Config, error) {
+	file, err := readConfigFile(os.Getenv(configFileEnv))
+	if err != nil {
+		return nil, err
+	}
+
+	src := &source{file: file, profile: EnvDevelopment}
+	src.profile = src.lookup("APP_ENV")
+	if _, ok := profileDefaults[src.profile]; !ok {
+		return nil, fmt.Errorf("invalid APP_ENV %q, must be %q, %q or %q", src.profile, EnvDevelopment, EnvTest, EnvProduction)
+	}
+
+	p := &parser{src: src}
+	cfg := &Config{
+		AppEnv:     src.profile,
+		AppPort:    p.port("PORT"),
+		DBHost:     p.string("DB_HOST"),
+		DBPort:     p.port("DB_PORT"),
+		DBUser:     p.string("DB_USER"),
+		DBPassword: p.string("DB_PASSWORD"),
+		DBName:     p.string("DB_NAME"),
+
+		DBSSLMode:          p.string("DB_SSLMODE"),
+		DBMaxIdleConns:     p.int("DB_MAX_IDLE_CONNS"),
+		DBMaxOpenConns:     p.int("DB_MAX_OPEN_CONNS"),
+		DBConnMaxLifetime:  p.duration("DB_CONN_MAX_LIFETIME"),
+		DBConnMaxIdleTime:  p.duration("DB_CONN_MAX_IDLE_TIME"),
+		DBStatementTimeout: p.duration("DB_STATEMENT_TIMEOUT"),
+		DBApplicationName:  p.string("DB_APPLICATION_NAME"),
+		DBReadReplicas:     p.list("DB_READ_REPLICAS"),
+
+		JWTSecret:       p.string("JWT_SECRET"),
+		JWTExpire:       p.duration("JWT_EXPIRED"),
+		RefreshTokenTTL: p.duration("REFRESH_TOKEN_EXPIRED"),
+
+		OrderingStrategy:      p.string("ORDERING_STRATEGY"),
+		RankRebalanceInterval: p.duration("RANK_REBALANCE_INTERVAL"),
+
+		StorageDriver:    p.string("STORAGE_DRIVER"),
+		StorageLocalPath: p.string("STORAGE_LOCAL_PATH"),
+		S3Endpoint:       p.string("S3_ENDPOINT"),
+		S3Region:         p.string("S3_REGION"),
+		S3Bucket:         p.string("S3_BUCKET"),
+		S3AccessKey:      p.string("S3_ACCESS_KEY"),
+		S3SecretKey:      p.string("S3_SECRET_KEY"),
+		S3UseSSL:         p.bool("S3_USE_SSL"),
+		MaxUploadSize:    p.int64("MAX_UPLOAD_SIZE"),
+
+		SeedAdminName:     p.string("SEED_ADMIN_NAME"),
+		SeedAdminEmail:    p.string("SEED_ADMIN_EMAIL"),
+		SeedAdminPassword: p.string("SEED_ADMIN_PASSWORD"),
+	}
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate mengecek aturan yang melibatkan arti nilai (bukan sekadar format),
+// misalnya pilihan yang valid dan secret yang tidak boleh default di production.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if !slices.Contains(dbSSLModes, c.DBSSLMode) {
+		invalid("invalid DB_SSLMODE %q, must be one of %s", c.DBSSLMode, strings.Join(dbSSLModes, ", "))
+	}
+	if c.DBMaxIdleConns < 0 || c.DBMaxOpenConns < 0 {
+		invalid("DB_MAX_IDLE_CONNS and DB_MAX_OPEN_CONNS must not be negative")
+	} else if c.DBMaxOpenConns > 0 && c.DBMaxIdleConns > c.DBMaxOpenConns {
+		invalid("DB_MAX_IDLE_CONNS (%d) must not exceed DB_MAX_OPEN_CONNS (%d)", c.DBMaxIdleConns, c.DBMaxOpenConns)
+	}
+	if c.DBConnMaxLifetime < 0 || c.DBConnMaxIdleTime < 0 || c.DBStatementTimeout < 0 {
+		invalid("DB_CONN_MAX_LIFETIME, DB_CONN_MAX_IDLE_TIME and DB_STATEMENT_TIMEOUT must not be negative")
+	} else if c.DBStatementTimeout > 0 && c.DBStatementTimeout < time.Millisecond {
+		invalid("DB_STATEMENT_TIMEOUT must be at least 1ms (PostgreSQL counts it in milliseconds)")
+	}
+
+	if c.JWTSecret == "" {
+		invalid("JWT_SECRET must be set")
+	} else if c.IsProduction() {
+		if c.JWTSecret == defaultJWTSecret {
+			invalid("JWT_SECRET must not be the default %q in production", defaultJWTSecret)
+		} else if len(c.JWTSecret) < minProductionJWTSecretLength {
+			invalid("JWT_SECRET must be at least %d characters in production", minProductionJWTSecretLength)
+		}
+	}
+	if c.JWTExpire <= 0 {
+		invalid("JWT_EXPIRED must be a positive duration")
+	}
+	if c.RefreshTokenTTL <= 0 {
+		invalid("REFRESH_TOKEN_EXPIRED must be a positive duration")
+	}
+
+	if c.OrderingStrategy != OrderingArray && c.OrderingStrategy != OrderingRank {
+		invalid("invalid ORDERING_STRATEGY %q, must be %q or %q", c.OrderingStrategy, OrderingArray, OrderingRank)
+	}
+	if c.RankRebalanceInterval <= 0 {
+		invalid("RANK_REBALANCE_INTERVAL must be a positive duration")
+	}
+
+	switch c.StorageDriver {
+	case StorageLocal:
+		if c.StorageLocalPath == "" {
+			invalid("STORAGE_LOCAL_PATH must be set when STORAGE_DRIVER=%s", StorageLocal)
+		}
+	case StorageS3:
+		if c.S3Endpoint == "" || c.S3Bucket == "" {
+			invalid("S3_ENDPOINT and S3_BUCKET must be set when STORAGE_DRIVER=%s", StorageS3)
+		}
+		if c.S3AccessKey == "" || c.S3SecretKey == "" {
+			invalid("S3_ACCESS_KEY and S3_SECRET_KEY must be set when STORAGE_DRIVER=%s", StorageS3)
+		}
+	default:
+		invalid("invalid STORAGE_DRIVER %q, must be %q or %q", c.StorageDriver, StorageLocal, StorageS3)
+	}
+	if c.MaxUploadSize <= 0 {
+		invalid("MAX_UPLOAD_SIZE must be a positive number of bytes")
+	}
+
+	return errors.Join(errs...)
+}
+
+// source mencari nilai mentah (string) dari lapisan-lapisan konfigurasi.
+type source struct {
+	file    map[string]string // Isi file YAML (CONFIG_FILE), boleh nil
+	profile string
+}
+
+// lookup mengembalikan nilai key dari lapisan tertinggi yang mengisinya.
+func (s *source) lookup(key string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	if value, ok := s.file[key]; ok {
+		return value
+	}
+	if value, ok := profileDefaults[s.profile][key]; ok {
+		return value
+	}
+	if key == "APP_ENV" {
+		return EnvDevelopment
+	}
+	return baseDefaults[key]
+}
+
+// parser mengubah nilai mentah menjadi tipe yang benar. Error tidak langsung dikembalikan
+// tapi dikumpulkan di errs, agar semua nilai yang salah bisa dilaporkan sekaligus.
+type parser struct {
+	src  *source
+	errs []error
+}
+
+func (p *parser) string(key string) string {
+	return strings.TrimSpace(p.src.lookup(key))
+}
+
+func (p *parser) int64(key string) int64 {
+	raw := p.string(key)
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("invalid %s %q, must be an integer", key, raw))
+	}
+	return value
+}
+
+func (p *parser) int(key string) int {
+	raw := p.string(key)
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("invalid %s %q, must be an integer", key, raw))
+	}
+	return value
+}
+
+func (p *parser) port(key string) int {
+	value := p.int64(key)
+	if value < 1 || value > 65535 {
+		p.errs = append(p.errs, fmt.Errorf("invalid %s %d, must be between 1 and 65535", key, value))
+	}
+	return int(value)
+}
+
+func (p *parser) bool(key string) bool {
+	raw := p.string(key)
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("invalid %s %q, must be true or false", key, raw))
+	}
+	return value
+}
+
+// duration mem-parse durasi seperti "15m" atau "24h". Nilai di-lowercase dulu karena
+// time.ParseDuration hanya menerima satuan huruf kecil, sedangkan .env lama memakai "24H".
+func (p *parser) duration(key string) time.Duration {
+	raw := p.string(key)
+	value, err := time.ParseDuration(strings.ToLower(raw))
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("invalid %s %q, must be a duration like \"15m\" or \"24h\"", key, raw))
+	}
+	return value
+}
+
+// list mem-parse daftar yang dipisah koma, misal "a, b". Item kosong dibuang,
+// sehingga nilai kosong menghasilkan slice nil.
+func (p *parser) list(key string) []string {
+	var values []string
+	for _, item := range strings.Split(p.string(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// readConfigFile membaca file YAML konfigurasi. path kosong berarti tidak ada file.
+// Key yang tidak dikenal ditolak agar salah ketik (misal "JWT_EXPIRE") tidak diam-diam diabaikan.
+func readConfigFile(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s %q: %w", configFileEnv, path, err)
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s %q: %w", configFileEnv, path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	var unknown []string
+	for key, value := range raw {
+		if _, ok := baseDefaults[key]; !ok && key != "APP_ENV" {
+			unknown = append(unknown, key)
+			continue
+		}
+		switch value.(type) {
+		case nil:
+			values[key] = ""
+		case map[string]interface{}, []interface{}:
+			return nil, fmt.Errorf("%s %q: %s must be a single value", configFileEnv, path, key)
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%s %q: unknown keys %s", configFileEnv, path, strings.Join(unknown, ", "))
+	}
+	return values, nil
+}
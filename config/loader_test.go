@@ -0,0 +1,221 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearConfigEnv menghapus semua env var konfigurasi selama test berjalan, agar hasil Load
+// tidak bergantung pada environment mesin. Nilai aslinya dikembalikan oleh t.Setenv.
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	keys := []string{"APP_ENV", configFileEnv}
+	for key := range baseDefaults {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+}
+
+// writeConfigFile menulis file YAML sementara lalu menunjuknya lewat CONFIG_FILE.
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	t.Setenv(configFileEnv, path)
+}
+
+func TestLoadDefaults(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.AppEnv != EnvDevelopment || cfg.AppPort != 3000 || cfg.DBName != "project_management" {
+		t.Errorf("Load = env %q, port %d, db %q; want development defaults", cfg.AppEnv, cfg.AppPort, cfg.DBName)
+	}
+	if cfg.JWTExpire != 15*time.Minute || cfg.RefreshTokenTTL != 24*time.Hour {
+		t.Errorf("token TTLs = %s / %s, want 15m / 24h", cfg.JWTExpire, cfg.RefreshTokenTTL)
+	}
+	if cfg.DBReadReplicas != nil {
+		t.Errorf("DBReadReplicas = %q, want nil", cfg.DBReadReplicas)
+	}
+	if cfg.MaxUploadSize != 10485760 || cfg.S3UseSSL {
+		t.Errorf("MaxUploadSize = %d, S3UseSSL = %v; want 10485760, false", cfg.MaxUploadSize, cfg.S3UseSSL)
+	}
+}
+
+func TestLoadTestProfile(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("APP_ENV", EnvTest)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.DBName != "project_management_test" || cfg.StorageLocalPath != "./uploads/test" {
+		t.Errorf("test profile = db %q, storage %q", cfg.DBName, cfg.StorageLocalPath)
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	clearConfigEnv(t)
+	writeConfigFile(t, "APP_ENV: test\nPORT: 8080\nDB_NAME: from_file\nJWT_EXPIRED: 5m\nS3_USE_SSL: true\n")
+	t.Setenv("DB_NAME", "from_env")
+	t.Setenv("JWT_EXPIRED", "24H")
+	t.Setenv("DB_READ_REPLICAS", " postgres://a , ,postgres://b ")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.AppEnv != EnvTest {
+		t.Errorf("AppEnv = %q, want %q from file", cfg.AppEnv, EnvTest)
+	}
+	if cfg.AppPort != 8080 || !cfg.S3UseSSL {
+		t.Errorf("PORT = %d, S3_USE_SSL = %v; want values from file", cfg.AppPort, cfg.S3UseSSL)
+	}
+	if cfg.DBName != "from_env" {
+		t.Errorf("DB_NAME = %q, want env to win over file", cfg.DBName)
+	}
+	if cfg.JWTExpire != 24*time.Hour {
+		t.Errorf("JWT_EXPIRED = %s, want upper-case unit parsed as 24h", cfg.JWTExpire)
+	}
+	if strings.Join(cfg.DBReadReplicas, "|") != "postgres://a|postgres://b" {
+		t.Errorf("DB_READ_REPLICAS = %q, want empty items dropped", cfg.DBReadReplicas)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		file     string
+		wantErrs []string
+	}{
+		{
+			name:     "unknown profile",
+			env:      map[string]string{"APP_ENV": "staging"},
+			wantErrs: []string{`invalid APP_ENV "staging"`},
+		},
+		{
+			name: "all parse errors are reported at once",
+			env: map[string]string{
+				"PORT":              "70000",
+				"DB_MAX_IDLE_CONNS": "ten",
+				"JWT_EXPIRED":       "soon",
+				"S3_USE_SSL":        "maybe",
+			},
+			wantErrs: []string{"invalid PORT 70000", `invalid DB_MAX_IDLE_CONNS "ten"`, `invalid JWT_EXPIRED "soon"`, `invalid S3_USE_SSL "maybe"`},
+		},
+		{
+			name:     "production requires secrets",
+			env:      map[string]string{"APP_ENV": EnvProduction},
+			wantErrs: []string{"JWT_SECRET must be set"},
+		},
+		{
+			name:     "unknown key in file",
+			file:     "JWT_EXPIRE: 5m\nPORT: 3000\n",
+			wantErrs: []string{"unknown keys JWT_EXPIRE"},
+		},
+		{
+			name:     "nested value in file",
+			file:     "DB_READ_REPLICAS:\n  - a\n  - b\n",
+			wantErrs: []string{"DB_READ_REPLICAS must be a single value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearConfigEnv(t)
+			if tt.file != "" {
+				writeConfigFile(t, tt.file)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			if err == nil {
+				t.Fatalf("Load = %+v, want error", cfg)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+// validConfig mengembalikan Config development yang lolos Validate.
+func validConfig(t *testing.T) *Config {
+	t.Helper()
+	clearConfigEnv(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string // Kosong berarti harus valid
+	}{
+		{"defaults", func(c *Config) {}, ""},
+		{"ssl mode", func(c *Config) { c.DBSSLMode = "on" }, `invalid DB_SSLMODE "on"`},
+		{"negative pool", func(c *Config) { c.DBMaxIdleConns = -1 }, "must not be negative"},
+		{"idle above open", func(c *Config) { c.DBMaxIdleConns, c.DBMaxOpenConns = 20, 10 }, "must not exceed DB_MAX_OPEN_CONNS"},
+		{"unlimited open conns", func(c *Config) { c.DBMaxIdleConns, c.DBMaxOpenConns = 20, 0 }, ""},
+		{"sub-millisecond statement timeout", func(c *Config) { c.DBStatementTimeout = time.Microsecond }, "at least 1ms"},
+		{"empty jwt secret", func(c *Config) { c.JWTSecret = "" }, "JWT_SECRET must be set"},
+		{"default secret in production", func(c *Config) { c.AppEnv = EnvProduction }, "must not be the default"},
+		{"short secret in production", func(c *Config) {
+			c.AppEnv, c.JWTSecret = EnvProduction, "short-secret"
+		}, "at least 32 characters"},
+		{"long secret in production", func(c *Config) {
+			c.AppEnv, c.JWTSecret = EnvProduction, strings.Repeat("s", minProductionJWTSecretLength)
+		}, ""},
+		{"zero jwt expiry", func(c *Config) { c.JWTExpire = 0 }, "JWT_EXPIRED must be a positive duration"},
+		{"zero refresh ttl", func(c *Config) { c.RefreshTokenTTL = 0 }, "REFRESH_TOKEN_EXPIRED must be a positive duration"},
+		{"ordering strategy", func(c *Config) { c.OrderingStrategy = "linked" }, `invalid ORDERING_STRATEGY "linked"`},
+		{"rank ordering", func(c *Config) { c.OrderingStrategy = OrderingRank }, ""},
+		{"zero rebalance interval", func(c *Config) { c.RankRebalanceInterval = 0 }, "RANK_REBALANCE_INTERVAL"},
+		{"storage driver", func(c *Config) { c.StorageDriver = "ftp" }, `invalid STORAGE_DRIVER "ftp"`},
+		{"local storage path", func(c *Config) { c.StorageLocalPath = "" }, "STORAGE_LOCAL_PATH must be set"},
+		{"s3 without keys", func(c *Config) { c.StorageDriver = StorageS3 }, "S3_ACCESS_KEY and S3_SECRET_KEY must be set"},
+		{"s3 with keys", func(c *Config) {
+			c.StorageDriver, c.S3AccessKey, c.S3SecretKey = StorageS3, "key", "secret"
+		}, ""},
+		{"upload size", func(c *Config) { c.MaxUploadSize = 0 }, "MAX_UPLOAD_SIZE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Package controllers berisi handler HTTP (Fiber).
+// Controller hanya bertugas: membaca request, memanggil service, lalu mengirim response
+// menggunakan helper di utils/response.go.
+package controllers
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// RegisterRequest adalah body JSON untuk POST /auth/register.
+type RegisterRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginRequest adalah body JSON untuk POST /auth/login.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// RefreshRequest adalah body JSON untuk POST /auth/refresh dan POST /auth/logout.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// minPasswordLength adalah panjang minimal password saat register.
+const minPasswordLength = 8
+
+// AuthController menangani endpoint /auth.
+type AuthController struct {
+	service        services.AuthService
+	sessionService services.SessionService
+}
+
+// NewAuthController membuat AuthController dengan dependency AuthService dan SessionService.
+func NewAuthController(service services.AuthService, sessionService services.SessionService) *AuthController {
+	return &AuthController{service: service, sessionService: sessionService}
+}
+
+// Register menangani POST /auth/register.
+func (ctrl *AuthController) Register(c *fiber.Ctx) error {
+	var req RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	// Semua field dicek sekaligus agar client bisa menampilkan semua kesalahan dalam satu kali kirim.
+	fieldErrors := map[string]string{}
+	if strings.TrimSpace(req.Name) == "" {
+		fieldErrors["name"] = "is required"
+	}
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		fieldErrors["email"] = "is not a valid email address"
+	}
+	if len(req.Password) < minPasswordLength {
+		fieldErrors["password"] = fmt.Sprintf("must be at least %d characters", minPasswordLength)
+	}
+	if len(fieldErrors) > 0 {
+		return utils.UnprocessableEntity(c, "Validation failed", fieldErrors)
+	}
+
+	result, err := ctrl.service.Register(req.Name, req.Email, req.Password)
+	if err != nil {
+		return respondError(c, "Registration failed", err)
+	}
+
+	return utils.Created(c, "Registration successful", result)
+}
+
+// Login menangani POST /auth/login.
+func (ctrl *AuthController) Login(c *fiber.Ctx) error {
+	var req LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	result, err := ctrl.service.Login(req.Email, req.Password)
+	if err != nil {
+		return respondError(c, "Login failed", err)
+	}
+
+	return utils.Success(c, "Login successful", result)
+}
+
+// Refresh menangani POST /auth/refresh.
+// Refresh token lama langsung tidak berlaku setelah endpoint ini dipanggil (rotasi).
+func (ctrl *AuthController) Refresh(c *fiber.Ctx) error {
+	var req RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		return utils.UnprocessableEntity(c, "Validation failed", map[string]string{"refresh_token": "is required"})
+	}
+
+	result, err := ctrl.service.Refresh(strings.TrimSpace(req.RefreshToken))
+	if err != nil {
+		return respondError(c, "Refresh failed", err)
+	}
+
+	return utils.Success(c, "Token refreshed successfully", result)
+}
+
+// Logout menangani POST /auth/logout: mengakhiri sesi yang sedang dipakai.
+// Body boleh kosong; jika berisi refresh_token, refresh token tersebut ikut dicabut.
+func (ctrl *AuthController) Logout(c *fiber.Ctx) error {
+	principal := middlewares.CurrentUser(c)
+	if principal == nil {
+		return utils.Unauthorized(c, "Token required", "No token provided")
+	}
+
+	var req RefreshRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.BadRequest(c, "Invalid request body", err.Error())
+		}
+	}
+
+	if err := ctrl.sessionService.Logout(principal, strings.TrimSpace(req.RefreshToken)); err != nil {
+		return utils.InternalServerError(c, "Logout failed", err.Error())
+	}
+
+	return utils.Success(c, "Logout successful", nil)
+}
+
+// LogoutAll menangani POST /auth/logout-all: mengakhiri semua sesi user di semua device.
+func (ctrl *AuthController) LogoutAll(c *fiber.Ctx) error {
+	principal := middlewares.CurrentUser(c)
+	if principal == nil {
+		return utils.Unauthorized(c, "Token required", "No token provided")
+	}
+
+	if err := ctrl.sessionService.LogoutAll(principal); err != nil {
+		return utils.InternalServerError(c, "Logout failed", err.Error())
+	}
+
+	return utils.Success(c, "All sessions logged out", nil)
+}
+
+// Me menangani GET /auth/me dan mengembalikan profil user pemilik token.
+// Route ini harus dilindungi middlewares.Protected().
+func (ctrl *AuthController) Me(c *fiber.Ctx) error {
+	principal := middlewares.CurrentUser(c)
+	if principal == nil {
+		return utils.Unauthorized(c, "Token required", "No token provided")
+	}
+
+	user, err := ctrl.service.Me(principal.PublicID)
+	if err != nil {
+		return respondError(c, "Failed to fetch user", err)
+	}
+
+	return utils.Success(c, "User retrieved successfully", user)
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// BoardController menangani endpoint /boards.
+type BoardController struct {
+	service services.BoardService
+}
+
+// NewBoardController membuat BoardController dengan dependency BoardService.
+func NewBoardController(service services.BoardService) *BoardController {
+	return &BoardController{service: service}
+}
+
+// Create menangani POST /boards.
+func (ctrl *BoardController) Create(c *fiber.Ctx) error {
+	var input services.CreateBoardInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	board, err := ctrl.service.Create(middlewares.CurrentUser(c), input)
+	if err != nil {
+		return respondError(c, "Failed to create board", err)
+	}
+
+	return utils.Created(c, "Board created successfully", board)
+}
+
+// List menangani GET /boards?page=1&limit=10 (board milik / yang diikuti user).
+func (ctrl *BoardController) List(c *fiber.Ctx) error {
+	page, limit := paginationParams(c)
+
+	boards, total, err := ctrl.service.List(middlewares.CurrentUser(c), page, limit)
+	if err != nil {
+		return respondError(c, "Failed to fetch boards", err)
+	}
+
+	return utils.SuccessPagination(c, "Boards retrieved successfully", boards, paginationMeta(page, limit, total))
+}
+
+// Get menangani GET /boards/:id.
+func (ctrl *BoardController) Get(c *fiber.Ctx) error {
+	publicID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	board, err := ctrl.service.GetByPublicID(middlewares.CurrentUser(c), publicID)
+	if err != nil {
+		return respondError(c, "Failed to fetch board", err)
+	}
+
+	return utils.Success(c, "Board retrieved successfully", board)
+}
+
+// Update menangani PUT /boards/:id.
+func (ctrl *BoardController) Update(c *fiber.Ctx) error {
+	publicID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	var input services.UpdateBoardInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	board, err := ctrl.service.Update(middlewares.CurrentUser(c), publicID, input)
+	if err != nil {
+		return respondError(c, "Failed to update board", err)
+	}
+
+	return utils.Success(c, "Board updated successfully", board)
+}
+
+// Delete menangani DELETE /boards/:id.
+func (ctrl *BoardController) Delete(c *fiber.Ctx) error {
+	publicID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	if err := ctrl.service.Delete(middlewares.CurrentUser(c), publicID); err != nil {
+		return respondError(c, "Failed to delete board", err)
+	}
+
+	return utils.Success(c, "Board deleted successfully", nil)
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// BoardMemberController menangani endpoint member dan undangan board.
+type BoardMemberController struct {
+	service services.BoardMemberService
+}
+
+// NewBoardMemberController membuat BoardMemberController dengan dependency BoardMemberService.
+func NewBoardMemberController(service services.BoardMemberService) *BoardMemberController {
+	return &BoardMemberController{service: service}
+}
+
+// Invite menangani POST /boards/:id/invitations.
+func (ctrl *BoardMemberController) Invite(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	var input services.InviteMemberInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	result, err := ctrl.service.Invite(middlewares.CurrentUser(c), boardID, input)
+	if err != nil {
+		return respondError(c, "Failed to invite member", err)
+	}
+
+	return utils.Created(c, "Invitation created successfully", result)
+}
+
+// ListInvitations menangani GET /boards/:id/invitations.
+func (ctrl *BoardMemberController) ListInvitations(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	invitations, err := ctrl.service.ListInvitations(middlewares.CurrentUser(c), boardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch invitations", err)
+	}
+
+	return utils.Success(c, "Invitations retrieved successfully", invitations)
+}
+
+// RevokeInvitation menangani DELETE /boards/:id/invitations/:invitation_id.
+func (ctrl *BoardMemberController) RevokeInvitation(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+	invitationID, err := parseUUIDParam(c, "invitation_id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid invitation id", err.Error())
+	}
+
+	if err := ctrl.service.RevokeInvitation(middlewares.CurrentUser(c), boardID, invitationID); err != nil {
+		return respondError(c, "Failed to revoke invitation", err)
+	}
+
+	return utils.Success(c, "Invitation revoked successfully", nil)
+}
+
+// Accept menangani POST /invitations/accept.
+func (ctrl *BoardMemberController) Accept(c *fiber.Ctx) error {
+	var input services.RespondInvitationInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	board, err := ctrl.service.AcceptInvitation(middlewares.CurrentUser(c), strings.TrimSpace(input.Token))
+	if err != nil {
+		return respondError(c, "Failed to accept invitation", err)
+	}
+
+	return utils.Success(c, "Invitation accepted successfully", board)
+}
+
+// Decline menangani POST /invitations/decline.
+func (ctrl *BoardMemberController) Decline(c *fiber.Ctx) error {
+	var input services.RespondInvitationInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	if err := ctrl.service.DeclineInvitation(middlewares.CurrentUser(c), strings.TrimSpace(input.Token)); err != nil {
+		return respondError(c, "Failed to decline invitation", err)
+	}
+
+	return utils.Success(c, "Invitation declined successfully", nil)
+}
+
+// ListMine menangani GET /invitations: undangan pending untuk email user yang login.
+func (ctrl *BoardMemberController) ListMine(c *fiber.Ctx) error {
+	invitations, err := ctrl.service.ListMyInvitations(middlewares.CurrentUser(c))
+	if err != nil {
+		return respondError(c, "Failed to fetch invitations", err)
+	}
+
+	return utils.Success(c, "Invitations retrieved successfully", invitations)
+}
+
+// AcceptByID menangani POST /invitations/:id/accept.
+func (ctrl *BoardMemberController) AcceptByID(c *fiber.Ctx) error {
+	invitationID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid invitation id", err.Error())
+	}
+
+	board, err := ctrl.service.AcceptInvitationByID(middlewares.CurrentUser(c), invitationID)
+	if err != nil {
+		return respondError(c, "Failed to accept invitation", err)
+	}
+
+	return utils.Success(c, "Invitation accepted successfully", board)
+}
+
+// DeclineByID menangani POST /invitations/:id/decline.
+func (ctrl *BoardMemberController) DeclineByID(c *fiber.Ctx) error {
+	invitationID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid invitation id", err.Error())
+	}
+
+	if err := ctrl.service.DeclineInvitationByID(middlewares.CurrentUser(c), invitationID); err != nil {
+		return respondError(c, "Failed to decline invitation", err)
+	}
+
+	return utils.Success(c, "Invitation declined successfully", nil)
+}
+
+// ListMembers menangani GET /boards/:id/members.
+func (ctrl *BoardMemberController) ListMembers(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	members, err := ctrl.service.ListMembers(middlewares.CurrentUser(c), boardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch members", err)
+	}
+
+	return utils.Success(c, "Members retrieved successfully", members)
+}
+
+// UpdateMemberRole menangani PATCH /boards/:id/members/:user_id.
+func (ctrl *BoardMemberController) UpdateMemberRole(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+	userID, err := parseUUIDParam(c, "user_id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user id", err.Error())
+	}
+
+	var input services.UpdateMemberRoleInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	if err := ctrl.service.UpdateMemberRole(middlewares.CurrentUser(c), boardID, userID, input); err != nil {
+		return respondError(c, "Failed to update member role", err)
+	}
+
+	return utils.Success(c, "Member role updated successfully", nil)
+}
+
+// RemoveMember menangani DELETE /boards/:id/members/:user_id.
+func (ctrl *BoardMemberController) RemoveMember(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+	userID, err := parseUUIDParam(c, "user_id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user id", err.Error())
+	}
+
+	if err := ctrl.service.RemoveMember(middlewares.CurrentUser(c), boardID, userID); err != nil {
+		return respondError(c, "Failed to remove member", err)
+	}
+
+	return utils.Success(c, "Member removed successfully", nil)
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// CardAssigneeController menangani endpoint assignee kartu.
+type CardAssigneeController struct {
+	service services.CardAssigneeService
+}
+
+// NewCardAssigneeController membuat CardAssigneeController dengan dependency CardAssigneeService.
+func NewCardAssigneeController(service services.CardAssigneeService) *CardAssigneeController {
+	return &CardAssigneeController{service: service}
+}
+
+// ListByCard menangani GET /cards/:id/assignees.
+func (ctrl *CardAssigneeController) ListByCard(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	assignees, err := ctrl.service.ListByCard(middlewares.CurrentUser(c), cardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch assignees", err)
+	}
+
+	return utils.Success(c, "Assignees retrieved successfully", assignees)
+}
+
+// Assign menangani PUT /cards/:id/assignees/:user_id.
+// Memakai PUT karena idempotent: menugaskan user yang sudah ditugaskan tidak dianggap error.
+func (ctrl *CardAssigneeController) Assign(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+	userID, err := parseUUIDParam(c, "user_id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user id", err.Error())
+	}
+
+	if err := ctrl.service.Assign(middlewares.CurrentUser(c), cardID, userID); err != nil {
+		return respondError(c, "Failed to assign user", err)
+	}
+
+	return utils.Success(c, "User assigned successfully", nil)
+}
+
+// Unassign menangani DELETE /cards/:id/assignees/:user_id.
+func (ctrl *CardAssigneeController) Unassign(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+	userID, err := parseUUIDParam(c, "user_id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user id", err.Error())
+	}
+
+	if err := ctrl.service.Unassign(middlewares.CurrentUser(c), cardID, userID); err != nil {
+		return respondError(c, "Failed to unassign user", err)
+	}
+
+	return utils.Success(c, "User unassigned successfully", nil)
+}
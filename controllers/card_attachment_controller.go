@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"mime"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// CardAttachmentController menangani endpoint attachment kartu.
+type CardAttachmentController struct {
+	service services.CardAttachmentService
+}
+
+// NewCardAttachmentController membuat CardAttachmentController dengan dependency CardAttachmentService.
+func NewCardAttachmentController(service services.CardAttachmentService) *CardAttachmentController {
+	return &CardAttachmentController{service: service}
+}
+
+// Upload menangani POST /cards/:id/attachments.
+// Body berupa multipart/form-data dengan field "file".
+func (ctrl *CardAttachmentController) Upload(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return utils.UnprocessableEntity(c, "Validation failed", map[string]string{"file": "is required"})
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return utils.InternalServerError(c, "Failed to upload attachment", err.Error())
+	}
+	defer file.Close()
+
+	attachment, err := ctrl.service.Upload(middlewares.CurrentUser(c), cardID, services.UploadAttachmentInput{
+		FileName: header.Filename,
+		Size:     header.Size,
+		Content:  file,
+	})
+	if err != nil {
+		return respondError(c, "Failed to upload attachment", err)
+	}
+
+	return utils.Created(c, "Attachment uploaded successfully", attachment)
+}
+
+// ListByCard menangani GET /cards/:id/attachments.
+func (ctrl *CardAttachmentController) ListByCard(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	attachments, err := ctrl.service.ListByCard(middlewares.CurrentUser(c), cardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch attachments", err)
+	}
+
+	return utils.Success(c, "Attachments retrieved successfully", attachments)
+}
+
+// Download menangani GET /attachments/:id/download.
+// Isi file di-stream langsung dari storage, tidak dimuat penuh ke memory.
+func (ctrl *CardAttachmentController) Download(c *fiber.Ctx) error {
+	attachmentID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid attachment id", err.Error())
+	}
+
+	download, err := ctrl.service.Download(middlewares.CurrentUser(c), attachmentID)
+	if err != nil {
+		return respondError(c, "Failed to download attachment", err)
+	}
+
+	// "attachment" memaksa browser menyimpan file, bukan membukanya di tab,
+	// dan nosniff mencegah browser menebak ulang content type (misal menjalankan HTML).
+	c.Set(fiber.HeaderContentType, download.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{
+		"filename": download.Attachment.FileName,
+	}))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+
+	// Body ditutup oleh Fiber (fasthttp) setelah response selesai dikirim.
+	return c.SendStream(download.Body, int(download.Size))
+}
+
+// Thumbnail menangani GET /attachments/:id/thumbnail.
+// Thumbnail selalu berupa JPEG yang dibuat server, jadi aman ditampilkan langsung (inline).
+func (ctrl *CardAttachmentController) Thumbnail(c *fiber.Ctx) error {
+	attachmentID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid attachment id", err.Error())
+	}
+
+	thumbnail, err := ctrl.service.Thumbnail(middlewares.CurrentUser(c), attachmentID)
+	if err != nil {
+		return respondError(c, "Failed to fetch thumbnail", err)
+	}
+
+	c.Set(fiber.HeaderContentType, thumbnail.ContentType)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	// Thumbnail tidak pernah berubah untuk attachment yang sama, jadi boleh di-cache browser.
+	// "private" mencegah proxy/CDN menyimpannya karena endpoint ini butuh login.
+	c.Set(fiber.HeaderCacheControl, "private, max-age=86400")
+
+	return c.SendStream(thumbnail.Body, int(thumbnail.Size))
+}
+
+// Delete menangani DELETE /attachments/:id.
+func (ctrl *CardAttachmentController) Delete(c *fiber.Ctx) error {
+	attachmentID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid attachment id", err.Error())
+	}
+
+	if err := ctrl.service.Delete(middlewares.CurrentUser(c), attachmentID); err != nil {
+		return respondError(c, "Failed to delete attachment", err)
+	}
+
+	return utils.Success(c, "Attachment deleted successfully", nil)
+}
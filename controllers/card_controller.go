@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// CardController menangani endpoint kartu.
+type CardController struct {
+	service services.CardService
+}
+
+// NewCardController membuat CardController dengan dependency CardService.
+func NewCardController(service services.CardService) *CardController {
+	return &CardController{service: service}
+}
+
+// Create menangani POST /lists/:id/cards.
+func (ctrl *CardController) Create(c *fiber.Ctx) error {
+	listID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid list id", err.Error())
+	}
+
+	var input services.CreateCardInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	card, err := ctrl.service.Create(middlewares.CurrentUser(c), listID, input)
+	if err != nil {
+		return respondError(c, "Failed to create card", err)
+	}
+
+	return utils.Created(c, "Card created successfully", card)
+}
+
+// ListByList menangani GET /lists/:id/cards (sudah terurut sesuai CardOrder).
+func (ctrl *CardController) ListByList(c *fiber.Ctx) error {
+	listID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid list id", err.Error())
+	}
+
+	cards, err := ctrl.service.ListByList(middlewares.CurrentUser(c), listID)
+	if err != nil {
+		return respondError(c, "Failed to fetch cards", err)
+	}
+
+	return utils.Success(c, "Cards retrieved successfully", cards)
+}
+
+// ListByBoard menangani GET /boards/:id/cards.
+//
+// Query opsional:
+//   - labels: daftar PublicID label dipisah koma, misal ?labels=<uuid>,<uuid>
+//   - match:  "any" (default, kartu punya salah satu label) atau "all" (kartu punya semua label)
+func (ctrl *CardController) ListByBoard(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	var filter services.CardFilter
+	for _, raw := range strings.Split(c.Query("labels"), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		labelID, err := uuid.Parse(raw)
+		if err != nil {
+			return utils.BadRequest(c, "Invalid label id", err.Error())
+		}
+		filter.LabelIDs = append(filter.LabelIDs, labelID)
+	}
+
+	switch c.Query("match", "any") {
+	case "any":
+	case "all":
+		filter.MatchAll = true
+	default:
+		return utils.BadRequest(c, "Invalid match parameter", "match must be either any or all")
+	}
+
+	cards, err := ctrl.service.ListByBoard(middlewares.CurrentUser(c), boardID, filter)
+	if err != nil {
+		return respondError(c, "Failed to fetch cards", err)
+	}
+
+	return utils.Success(c, "Cards retrieved successfully", cards)
+}
+
+// Get menangani GET /cards/:id.
+func (ctrl *CardController) Get(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	card, err := ctrl.service.Get(middlewares.CurrentUser(c), cardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch card", err)
+	}
+
+	return utils.Success(c, "Card retrieved successfully", card)
+}
+
+// Update menangani PATCH /cards/:id.
+func (ctrl *CardController) Update(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	var input services.UpdateCardInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	card, err := ctrl.service.Update(middlewares.CurrentUser(c), cardID, input)
+	if err != nil {
+		return respondError(c, "Failed to update card", err)
+	}
+
+	return utils.Success(c, "Card updated successfully", card)
+}
+
+// Delete menangani DELETE /cards/:id.
+func (ctrl *CardController) Delete(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	if err := ctrl.service.Delete(middlewares.CurrentUser(c), cardID); err != nil {
+		return respondError(c, "Failed to delete card", err)
+	}
+
+	return utils.Success(c, "Card deleted successfully", nil)
+}
+
+// Move menangani PATCH /cards/:id/move.
+func (ctrl *CardController) Move(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	var input services.MoveCardInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	card, err := ctrl.service.Move(middlewares.CurrentUser(c), cardID, input)
+	if err != nil {
+		return respondError(c, "Failed to move card", err)
+	}
+
+	return utils.Success(c, "Card moved successfully", card)
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// ChecklistController menangani endpoint checklist kartu beserta item-nya.
+type ChecklistController struct {
+	service services.ChecklistService
+}
+
+// NewChecklistController membuat ChecklistController dengan dependency ChecklistService.
+func NewChecklistController(service services.ChecklistService) *ChecklistController {
+	return &ChecklistController{service: service}
+}
+
+// Create menangani POST /cards/:id/checklists.
+func (ctrl *ChecklistController) Create(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	var input services.CreateChecklistInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	checklist, err := ctrl.service.Create(middlewares.CurrentUser(c), cardID, input)
+	if err != nil {
+		return respondError(c, "Failed to create checklist", err)
+	}
+
+	return utils.Created(c, "Checklist created successfully", checklist)
+}
+
+// ListByCard menangani GET /cards/:id/checklists.
+func (ctrl *ChecklistController) ListByCard(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	checklists, err := ctrl.service.ListByCard(middlewares.CurrentUser(c), cardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch checklists", err)
+	}
+
+	return utils.Success(c, "Checklists retrieved successfully", checklists)
+}
+
+// Update menangani PATCH /checklists/:id.
+func (ctrl *ChecklistController) Update(c *fiber.Ctx) error {
+	checklistID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid checklist id", err.Error())
+	}
+
+	var input services.UpdateChecklistInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	checklist, err := ctrl.service.Update(middlewares.CurrentUser(c), checklistID, input)
+	if err != nil {
+		return respondError(c, "Failed to update checklist", err)
+	}
+
+	return utils.Success(c, "Checklist updated successfully", checklist)
+}
+
+// Delete menangani DELETE /checklists/:id.
+func (ctrl *ChecklistController) Delete(c *fiber.Ctx) error {
+	checklistID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid checklist id", err.Error())
+	}
+
+	if err := ctrl.service.Delete(middlewares.CurrentUser(c), checklistID); err != nil {
+		return respondError(c, "Failed to delete checklist", err)
+	}
+
+	return utils.Success(c, "Checklist deleted successfully", nil)
+}
+
+// CreateItem menangani POST /checklists/:id/items.
+func (ctrl *ChecklistController) CreateItem(c *fiber.Ctx) error {
+	checklistID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid checklist id", err.Error())
+	}
+
+	var input services.CreateChecklistItemInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	item, err := ctrl.service.CreateItem(middlewares.CurrentUser(c), checklistID, input)
+	if err != nil {
+		return respondError(c, "Failed to create checklist item", err)
+	}
+
+	return utils.Created(c, "Checklist item created successfully", item)
+}
+
+// UpdateItem menangani PATCH /checklist-items/:id.
+func (ctrl *ChecklistController) UpdateItem(c *fiber.Ctx) error {
+	itemID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid checklist item id", err.Error())
+	}
+
+	var input services.UpdateChecklistItemInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	item, err := ctrl.service.UpdateItem(middlewares.CurrentUser(c), itemID, input)
+	if err != nil {
+		return respondError(c, "Failed to update checklist item", err)
+	}
+
+	return utils.Success(c, "Checklist item updated successfully", item)
+}
+
+// DeleteItem menangani DELETE /checklist-items/:id.
+func (ctrl *ChecklistController) DeleteItem(c *fiber.Ctx) error {
+	itemID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid checklist item id", err.Error())
+	}
+
+	if err := ctrl.service.DeleteItem(middlewares.CurrentUser(c), itemID); err != nil {
+		return respondError(c, "Failed to delete checklist item", err)
+	}
+
+	return utils.Success(c, "Checklist item deleted successfully", nil)
+}
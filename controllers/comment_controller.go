@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// CommentController menangani endpoint komentar kartu.
+type CommentController struct {
+	service services.CommentService
+}
+
+// NewCommentController membuat CommentController dengan dependency CommentService.
+func NewCommentController(service services.CommentService) *CommentController {
+	return &CommentController{service: service}
+}
+
+// Create menangani POST /cards/:id/comments.
+func (ctrl *CommentController) Create(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	var input services.CreateCommentInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	comment, err := ctrl.service.Create(middlewares.CurrentUser(c), cardID, input)
+	if err != nil {
+		return respondError(c, "Failed to create comment", err)
+	}
+
+	return utils.Created(c, "Comment created successfully", comment)
+}
+
+// ListByCard menangani GET /cards/:id/comments.
+func (ctrl *CommentController) ListByCard(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	comments, err := ctrl.service.ListByCard(middlewares.CurrentUser(c), cardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch comments", err)
+	}
+
+	return utils.Success(c, "Comments retrieved successfully", comments)
+}
+
+// Update menangani PATCH /comments/:id.
+func (ctrl *CommentController) Update(c *fiber.Ctx) error {
+	commentID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid comment id", err.Error())
+	}
+
+	var input services.UpdateCommentInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	comment, err := ctrl.service.Update(middlewares.CurrentUser(c), commentID, input)
+	if err != nil {
+		return respondError(c, "Failed to update comment", err)
+	}
+
+	return utils.Success(c, "Comment updated successfully", comment)
+}
+
+// Delete menangani DELETE /comments/:id.
+func (ctrl *CommentController) Delete(c *fiber.Ctx) error {
+	commentID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid comment id", err.Error())
+	}
+
+	if err := ctrl.service.Delete(middlewares.CurrentUser(c), commentID); err != nil {
+		return respondError(c, "Failed to delete comment", err)
+	}
+
+	return utils.Success(c, "Comment deleted successfully", nil)
+}
+
+// ListRevisions menangani GET /comments/:id/revisions.
+func (ctrl *CommentController) ListRevisions(c *fiber.Ctx) error {
+	commentID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid comment id", err.Error())
+	}
+
+	revisions, err := ctrl.service.ListRevisions(middlewares.CurrentUser(c), commentID)
+	if err != nil {
+		return respondError(c, "Failed to fetch comment revisions", err)
+	}
+
+	return utils.Success(c, "Comment revisions retrieved successfully", revisions)
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/buildinfo"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// HealthController menangani endpoint probe untuk orchestrator (Docker, Kubernetes, load balancer).
+// Semua endpoint ini TIDAK memakai middleware auth.
+type HealthController struct {
+	healthService services.HealthService
+}
+
+// NewHealthController membuat HealthController dengan dependency yang dibutuhkan.
+func NewHealthController(healthService services.HealthService) *HealthController {
+	return &HealthController{healthService: healthService}
+}
+
+// Healthz menangani GET /healthz (liveness probe).
+// Hanya menandakan proses hidup dan bisa menjawab request; sengaja tidak mengecek database,
+// agar gangguan database tidak membuat orchestrator me-restart semua instance.
+func (ctrl *HealthController) Healthz(c *fiber.Ctx) error {
+	return utils.Success(c, "OK", nil)
+}
+
+// Readyz menangani GET /readyz (readiness probe).
+// Mengembalikan 200 jika database, migration, dan storage siap; 503 jika ada yang gagal,
+// sehingga instance ini dikeluarkan dari load balancer sampai pulih.
+//
+// Endpoint ini publik, jadi detail error pengecekan yang gagal selalu dicatat di log server
+// dan di production TIDAK dikirim ke client (bisa berisi host database, bucket, dll);
+// client hanya melihat nama dan status setiap pengecekan.
+func (ctrl *HealthController) Readyz(c *fiber.Ctx) error {
+	readiness := ctrl.healthService.Readiness(c.UserContext())
+	if !readiness.Ready {
+		production := config.AppConfig != nil && config.AppConfig.IsProduction()
+		for i, check := range readiness.Checks {
+			if check.Error == "" {
+				continue
+			}
+			log.Printf("readiness check %s failed: %s", check.Name, check.Error)
+			if production {
+				readiness.Checks[i].Error = ""
+			}
+		}
+		return utils.ServiceUnavailable(c, "Service is not ready", readiness)
+	}
+	return utils.Success(c, "Service is ready", readiness)
+}
+
+// Version menangani GET /version.
+// Mengembalikan metadata build yang diisi saat link (lihat package buildinfo).
+func (ctrl *HealthController) Version(c *fiber.Ctx) error {
+	return utils.Success(c, "Build info retrieved successfully", buildinfo.Get())
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+const (
+	// defaultPageLimit adalah jumlah data per halaman jika query ?limit tidak diisi.
+	defaultPageLimit = 10
+
+	// maxPageLimit membatasi ?limit agar client tidak bisa meminta jutaan baris sekaligus.
+	maxPageLimit = 100
+)
+
+// serviceError memetakan satu error service ke HTTP status dan kode error untuk client.
+type serviceError struct {
+	err    error
+	status int
+	code   utils.ErrorCode
+}
+
+// serviceErrors adalah daftar error service beserta HTTP status dan kode yang sesuai.
+// Error yang tidak ada di daftar ini diteruskan ke utils.AsAppError (misal
+// gorm.ErrRecordNotFound -> 404), dan sisanya dianggap error tak terduga (HTTP 500).
+var serviceErrors = []serviceError{
+	{services.ErrInvalidInput, fiber.StatusBadRequest, utils.CodeInvalidInput},
+	{services.ErrCannotRemoveOwner, fiber.StatusBadRequest, utils.CodeCannotRemoveOwner},
+	{services.ErrCannotChangeOwner, fiber.StatusBadRequest, utils.CodeCannotChangeOwner},
+	{services.ErrInvitationExpired, fiber.StatusBadRequest, utils.CodeInvitationExpired},
+	{services.ErrInvitationNotPending, fiber.StatusBadRequest, utils.CodeInvitationNotPending},
+	{services.ErrAssigneeNotMember, fiber.StatusBadRequest, utils.CodeAssigneeNotMember},
+
+	{services.ErrInvalidCredentials, fiber.StatusUnauthorized, utils.CodeInvalidCredentials},
+	{services.ErrInvalidRefreshToken, fiber.StatusUnauthorized, utils.CodeInvalidRefreshToken},
+	{services.ErrRefreshTokenReused, fiber.StatusUnauthorized, utils.CodeRefreshTokenReused},
+
+	{services.ErrForbidden, fiber.StatusForbidden, utils.CodeForbidden},
+	{services.ErrInvitationEmailMismatch, fiber.StatusForbidden, utils.CodeInvitationEmailMismatch},
+
+	{services.ErrUserNotFound, fiber.StatusNotFound, utils.CodeUserNotFound},
+	{services.ErrBoardNotFound, fiber.StatusNotFound, utils.CodeBoardNotFound},
+	{services.ErrListNotFound, fiber.StatusNotFound, utils.CodeListNotFound},
+	{services.ErrCardNotFound, fiber.StatusNotFound, utils.CodeCardNotFound},
+	{services.ErrCommentNotFound, fiber.StatusNotFound, utils.CodeCommentNotFound},
+	{services.ErrLabelNotFound, fiber.StatusNotFound, utils.CodeLabelNotFound},
+	{services.ErrAttachmentNotFound, fiber.StatusNotFound, utils.CodeAttachmentNotFound},
+	{services.ErrChecklistNotFound, fiber.StatusNotFound, utils.CodeChecklistNotFound},
+	{services.ErrChecklistItemNotFound, fiber.StatusNotFound, utils.CodeChecklistItemNotFound},
+	{services.ErrMemberNotFound, fiber.StatusNotFound, utils.CodeMemberNotFound},
+	{services.ErrInvitationNotFound, fiber.StatusNotFound, utils.CodeInvitationNotFound},
+
+	{services.ErrEmailAlreadyUsed, fiber.StatusConflict, utils.CodeEmailAlreadyUsed},
+	{services.ErrAlreadyMember, fiber.StatusConflict, utils.CodeAlreadyMember},
+	{services.ErrCardMovedConcurrently, fiber.StatusConflict, utils.CodeCardMovedConcurrently},
+
+	{services.ErrFileTooLarge, fiber.StatusRequestEntityTooLarge, utils.CodeFileTooLarge},
+}
+
+// respondError memetakan error dari service ke response HTTP yang sesuai.
+// Dengan begini setiap handler cukup memanggil satu fungsi, dan pemetaan error
+// ke status code terkumpul di satu tempat.
+func respondError(c *fiber.Ctx, message string, err error) error {
+	for _, mapping := range serviceErrors {
+		if errors.Is(err, mapping.err) {
+			return utils.SendError(c, utils.NewAppError(mapping.status, mapping.code, message).Wrap(err))
+		}
+	}
+	return utils.SendError(c, utils.AsAppError(err, message))
+}
+
+// parseUUIDParam membaca path parameter (misal ":id") dan mengubahnya menjadi UUID.
+func parseUUIDParam(c *fiber.Ctx, name string) (uuid.UUID, error) {
+	return uuid.Parse(c.Params(name))
+}
+
+// paginationParams membaca query ?page dan ?limit dengan nilai default yang aman.
+func paginationParams(c *fiber.Ctx) (page int, limit int) {
+	page = c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	limit = c.QueryInt("limit", defaultPageLimit)
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	return page, limit
+}
+
+// paginationMeta menyusun utils.PaginationMeta dari hasil query.
+func paginationMeta(page, limit int, total int64) utils.PaginationMeta {
+	totalPage := int((total + int64(limit) - 1) / int64(limit))
+	return utils.PaginationMeta{
+		Page:      page,
+		Limit:     limit,
+		Total:     int(total),
+		TotalPage: totalPage,
+	}
+}
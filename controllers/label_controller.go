@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// LabelController menangani endpoint label board dan label di kartu.
+type LabelController struct {
+	service services.LabelService
+}
+
+// NewLabelController membuat LabelController dengan dependency LabelService.
+func NewLabelController(service services.LabelService) *LabelController {
+	return &LabelController{service: service}
+}
+
+// Create menangani POST /boards/:id/labels.
+func (ctrl *LabelController) Create(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	var input services.CreateLabelInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	label, err := ctrl.service.Create(middlewares.CurrentUser(c), boardID, input)
+	if err != nil {
+		return respondError(c, "Failed to create label", err)
+	}
+
+	return utils.Created(c, "Label created successfully", label)
+}
+
+// ListByBoard menangani GET /boards/:id/labels.
+func (ctrl *LabelController) ListByBoard(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	labels, err := ctrl.service.ListByBoard(middlewares.CurrentUser(c), boardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch labels", err)
+	}
+
+	return utils.Success(c, "Labels retrieved successfully", labels)
+}
+
+// Update menangani PATCH /labels/:id.
+func (ctrl *LabelController) Update(c *fiber.Ctx) error {
+	labelID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label id", err.Error())
+	}
+
+	var input services.UpdateLabelInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	label, err := ctrl.service.Update(middlewares.CurrentUser(c), labelID, input)
+	if err != nil {
+		return respondError(c, "Failed to update label", err)
+	}
+
+	return utils.Success(c, "Label updated successfully", label)
+}
+
+// Delete menangani DELETE /labels/:id.
+func (ctrl *LabelController) Delete(c *fiber.Ctx) error {
+	labelID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label id", err.Error())
+	}
+
+	if err := ctrl.service.Delete(middlewares.CurrentUser(c), labelID); err != nil {
+		return respondError(c, "Failed to delete label", err)
+	}
+
+	return utils.Success(c, "Label deleted successfully", nil)
+}
+
+// ListByCard menangani GET /cards/:id/labels.
+func (ctrl *LabelController) ListByCard(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+
+	labels, err := ctrl.service.ListByCard(middlewares.CurrentUser(c), cardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch card labels", err)
+	}
+
+	return utils.Success(c, "Card labels retrieved successfully", labels)
+}
+
+// Attach menangani PUT /cards/:id/labels/:label_id.
+// Memakai PUT karena idempotent: menempelkan label yang sudah tertempel tidak dianggap error.
+func (ctrl *LabelController) Attach(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+	labelID, err := parseUUIDParam(c, "label_id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label id", err.Error())
+	}
+
+	if err := ctrl.service.Attach(middlewares.CurrentUser(c), cardID, labelID); err != nil {
+		return respondError(c, "Failed to attach label", err)
+	}
+
+	return utils.Success(c, "Label attached successfully", nil)
+}
+
+// Detach menangani DELETE /cards/:id/labels/:label_id.
+func (ctrl *LabelController) Detach(c *fiber.Ctx) error {
+	cardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid card id", err.Error())
+	}
+	labelID, err := parseUUIDParam(c, "label_id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid label id", err.Error())
+	}
+
+	if err := ctrl.service.Detach(middlewares.CurrentUser(c), cardID, labelID); err != nil {
+		return respondError(c, "Failed to detach label", err)
+	}
+
+	return utils.Success(c, "Label detached successfully", nil)
+}
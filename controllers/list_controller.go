@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// ListController menangani endpoint list.
+type ListController struct {
+	service services.ListService
+}
+
+// NewListController membuat ListController dengan dependency ListService.
+func NewListController(service services.ListService) *ListController {
+	return &ListController{service: service}
+}
+
+// Create menangani POST /boards/:id/lists.
+func (ctrl *ListController) Create(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	var input services.CreateListInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	list, err := ctrl.service.Create(middlewares.CurrentUser(c), boardID, input)
+	if err != nil {
+		return respondError(c, "Failed to create list", err)
+	}
+
+	return utils.Created(c, "List created successfully", list)
+}
+
+// ListByBoard menangani GET /boards/:id/lists (sudah terurut sesuai ListOrder).
+func (ctrl *ListController) ListByBoard(c *fiber.Ctx) error {
+	boardID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid board id", err.Error())
+	}
+
+	lists, err := ctrl.service.ListByBoard(middlewares.CurrentUser(c), boardID)
+	if err != nil {
+		return respondError(c, "Failed to fetch lists", err)
+	}
+
+	return utils.Success(c, "Lists retrieved successfully", lists)
+}
+
+// Update menangani PATCH /lists/:id (rename).
+func (ctrl *ListController) Update(c *fiber.Ctx) error {
+	listID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid list id", err.Error())
+	}
+
+	var input services.UpdateListInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	list, err := ctrl.service.Update(middlewares.CurrentUser(c), listID, input)
+	if err != nil {
+		return respondError(c, "Failed to update list", err)
+	}
+
+	return utils.Success(c, "List updated successfully", list)
+}
+
+// Delete menangani DELETE /lists/:id.
+func (ctrl *ListController) Delete(c *fiber.Ctx) error {
+	listID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid list id", err.Error())
+	}
+
+	if err := ctrl.service.Delete(middlewares.CurrentUser(c), listID); err != nil {
+		return respondError(c, "Failed to delete list", err)
+	}
+
+	return utils.Success(c, "List deleted successfully", nil)
+}
+
+// Move menangani PATCH /lists/:id/move.
+func (ctrl *ListController) Move(c *fiber.Ctx) error {
+	listID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid list id", err.Error())
+	}
+
+	var input services.MoveListInput
+	if err := c.BodyParser(&input); err != nil {
+		return utils.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	if err := ctrl.service.Move(middlewares.CurrentUser(c), listID, input); err != nil {
+		return respondError(c, "Failed to move list", err)
+	}
+
+	return utils.Success(c, "List moved successfully", nil)
+}
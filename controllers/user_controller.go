@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// UserController menangani endpoint /users.
+type UserController struct {
+	sessionService  services.SessionService
+	assigneeService services.CardAssigneeService
+}
+
+// NewUserController membuat UserController dengan dependency yang dibutuhkan.
+func NewUserController(sessionService services.SessionService, assigneeService services.CardAssigneeService) *UserController {
+	return &UserController{sessionService: sessionService, assigneeService: assigneeService}
+}
+
+// RevokeTokens menangani POST /users/:id/revoke-tokens (khusus admin).
+// Semua access token dan refresh token milik user tersebut langsung tidak berlaku.
+func (ctrl *UserController) RevokeTokens(c *fiber.Ctx) error {
+	publicID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user id", err.Error())
+	}
+
+	if err := ctrl.sessionService.RevokeUserSessions(publicID); err != nil {
+		return respondError(c, "Failed to revoke tokens", err)
+	}
+
+	return utils.Success(c, "User tokens revoked successfully", nil)
+}
+
+// Workload menangani GET /users/:id/workload.
+// Mengembalikan kartu yang ditugaskan ke user, dikelompokkan per board lalu per due date.
+func (ctrl *UserController) Workload(c *fiber.Ctx) error {
+	publicID, err := parseUUIDParam(c, "id")
+	if err != nil {
+		return utils.BadRequest(c, "Invalid user id", err.Error())
+	}
+
+	workload, err := ctrl.assigneeService.Workload(middlewares.CurrentUser(c), publicID)
+	if err != nil {
+		return respondError(c, "Failed to fetch workload", err)
+	}
+
+	return utils.Success(c, "Workload retrieved successfully", workload)
+}
@@ -0,0 +1,251 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Jenis perbedaan antara model GORM dan skema database.
+const (
+	DriftMissingTable  = "missing_table"
+	DriftMissingColumn = "missing_column"
+	DriftTypeMismatch  = "type_mismatch"
+	DriftMissingIndex  = "missing_index"
+)
+
+// Drift adalah satu perbedaan antara model GORM dan skema database yang sedang berjalan.
+type Drift struct {
+	Table  string
+	Kind   string   // Salah satu konstanta Drift*
+	Column string   // Kosong untuk DriftMissingTable dan DriftMissingIndex
+	Detail string   // Penjelasan singkat, misal "model expects timestamptz, database has timestamp"
+	Fields []string // Kolom index yang hilang (hanya untuk DriftMissingIndex)
+}
+
+// String memformat Drift agar mudah dibaca di terminal.
+func (d Drift) String() string {
+	switch d.Kind {
+	case DriftMissingTable:
+		return fmt.Sprintf("%s: table does not exist", d.Table)
+	case DriftMissingIndex:
+		return fmt.Sprintf("%s (%s): %s", d.Table, strings.Join(d.Fields, ", "), d.Detail)
+	default:
+		return fmt.Sprintf("%s.%s: %s", d.Table, d.Column, d.Detail)
+	}
+}
+
+// dbColumn adalah baris dari information_schema.columns.
+type dbColumn struct {
+	ColumnName string
+	UdtName    string // Nama tipe internal PostgreSQL, misal "int8", "timestamptz", "_uuid"
+}
+
+// dbIndex adalah index (atau constraint UNIQUE / PRIMARY KEY) yang ada di database.
+type dbIndex struct {
+	Unique  bool
+	Columns string // Nama kolom dipisah koma, sesuai urutan di index
+}
+
+// expectedIndex adalah index yang dideklarasikan model lewat tag GORM.
+type expectedIndex struct {
+	unique  bool
+	columns []string
+}
+
+// CheckDrift membandingkan metadata GORM dari setiap model dengan katalog PostgreSQL
+// (schema aktif, biasanya "public") dan mengembalikan semua perbedaan yang ditemukan:
+//   - tabel atau kolom yang ada di model tapi tidak ada di database,
+//   - tipe kolom yang berbeda (misal TIMESTAMP vs TIMESTAMPTZ),
+//   - index dari tag primaryKey, unique, index, dan uniqueIndex yang tidak ada di database.
+//
+// Tipe dibandingkan per "keluarga" agar perbedaan yang tidak berbahaya tidak dilaporkan:
+// varchar(255) dan text sama-sama teks, integer dan bigint sama-sama bilangan bulat.
+// Index dicocokkan berdasarkan kolomnya, bukan namanya, karena migration boleh memakai
+// nama sendiri. Index biasa juga terpenuhi oleh index komposit yang diawali kolom yang sama.
+func CheckDrift(ctx context.Context, db *gorm.DB, models []interface{}) ([]Drift, error) {
+	db = db.WithContext(ctx)
+	cache := &sync.Map{}
+
+	var drifts []Drift
+	for _, model := range models {
+		sch, err := schema.Parse(model, cache, db.NamingStrategy)
+		if err != nil {
+			return nil, fmt.Errorf("parse model %T: %w", model, err)
+		}
+
+		var columns []dbColumn
+		if err := db.Raw(`
+			SELECT column_name, udt_name FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ?`, sch.Table).
+			Scan(&columns).Error; err != nil {
+			return nil, err
+		}
+		if len(columns) == 0 {
+			drifts = append(drifts, Drift{Table: sch.Table, Kind: DriftMissingTable})
+			continue
+		}
+
+		actualTypes := make(map[string]string, len(columns))
+		for _, column := range columns {
+			actualTypes[column.ColumnName] = column.UdtName
+		}
+		for _, name := range sch.DBNames {
+			field := sch.FieldsByDBName[name]
+			if field.IgnoreMigration {
+				continue
+			}
+
+			expected := expectedType(db, field)
+			actual, ok := actualTypes[name]
+			if !ok {
+				drifts = append(drifts, Drift{
+					Table: sch.Table, Kind: DriftMissingColumn, Column: name,
+					Detail: fmt.Sprintf("column does not exist (model expects %s)", expected),
+				})
+				continue
+			}
+			if typeFamily(expected) != typeFamily(actual) {
+				drifts = append(drifts, Drift{
+					Table: sch.Table, Kind: DriftTypeMismatch, Column: name,
+					Detail: fmt.Sprintf("model expects %s, database has %s", expected, actual),
+				})
+			}
+		}
+
+		var indexes []dbIndex
+		if err := db.Raw(`
+			SELECT i.indisunique AS "unique", string_agg(a.attname::text, ',' ORDER BY k.ord) AS columns
+			FROM pg_index i
+			JOIN pg_class t ON t.oid = i.indrelid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			CROSS JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, ord)
+			JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+			WHERE n.nspname = current_schema() AND t.relname = ? AND i.indpred IS NULL
+			GROUP BY i.indexrelid, i.indisunique`, sch.Table).
+			Scan(&indexes).Error; err != nil {
+			return nil, err
+		}
+		for _, index := range expectedIndexes(sch) {
+			if !hasIndex(indexes, index) {
+				detail := "index does not exist"
+				if index.unique {
+					detail = "unique index does not exist"
+				}
+				drifts = append(drifts, Drift{
+					Table: sch.Table, Kind: DriftMissingIndex, Fields: index.columns, Detail: detail,
+				})
+			}
+		}
+	}
+	return drifts, nil
+}
+
+// expectedType mengembalikan tipe kolom PostgreSQL yang diharapkan untuk field model.
+func expectedType(db *gorm.DB, field *schema.Field) string {
+	// uuid.UUID disimpan GORM lewat Value() yang mengembalikan string, sehingga DataTypeOf
+	// menganggapnya "text". Di repo ini kolom UUID selalu bertipe uuid.
+	fieldType := field.FieldType
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType == reflect.TypeOf(uuid.UUID{}) && field.TagSettings["TYPE"] == "" {
+		return "uuid"
+	}
+	return db.Dialector.DataTypeOf(field)
+}
+
+// typeFamily mengelompokkan nama tipe PostgreSQL (baik dari DataTypeOf GORM maupun udt_name
+// katalog) ke keluarga yang sama, misal "varchar(255)", "text", dan "bpchar" menjadi "text".
+func typeFamily(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+
+	switch name {
+	case "smallint", "integer", "bigint", "smallserial", "serial", "bigserial", "int2", "int4", "int8":
+		return "integer"
+	case "text", "varchar", "character varying", "char", "character", "bpchar":
+		return "text"
+	case "boolean", "bool":
+		return "boolean"
+	case "timestamptz", "timestamp with time zone":
+		return "timestamptz"
+	case "timestamp", "timestamp without time zone":
+		return "timestamp"
+	case "decimal", "numeric", "real", "double precision", "float4", "float8":
+		return "numeric"
+	case "uuid[]", "_uuid":
+		return "uuid[]"
+	}
+	return name
+}
+
+// expectedIndexes mengumpulkan index yang dideklarasikan model: primary key,
+// tag unique, serta tag index / uniqueIndex.
+func expectedIndexes(sch *schema.Schema) []expectedIndex {
+	var indexes []expectedIndex
+	if len(sch.PrimaryFieldDBNames) > 0 {
+		indexes = append(indexes, expectedIndex{unique: true, columns: sch.PrimaryFieldDBNames})
+	}
+	for _, field := range sch.Fields {
+		if field.Unique && field.DBName != "" {
+			indexes = append(indexes, expectedIndex{unique: true, columns: []string{field.DBName}})
+		}
+	}
+	for _, index := range sch.ParseIndexes() {
+		expected := expectedIndex{unique: index.Class == "UNIQUE"}
+		for _, option := range index.Fields {
+			// Index berbasis ekspresi tidak bisa dicocokkan dengan nama kolom.
+			if option.Field == nil || option.Expression != "" {
+				expected.columns = nil
+				break
+			}
+			expected.columns = append(expected.columns, option.DBName)
+		}
+		if len(expected.columns) > 0 {
+			indexes = append(indexes, expected)
+		}
+	}
+	return indexes
+}
+
+// hasIndex mengecek apakah expected sudah dipenuhi salah satu index database.
+//
+// Index unique harus punya kolom yang persis sama (urutan bebas). Index biasa cukup
+// menjadi awalan (prefix) dari index database, karena index (a, b) juga mempercepat query WHERE a.
+func hasIndex(indexes []dbIndex, expected expectedIndex) bool {
+	for _, index := range indexes {
+		columns := strings.Split(index.Columns, ",")
+		if expected.unique {
+			if index.Unique && sameColumns(columns, expected.columns) {
+				return true
+			}
+			continue
+		}
+		if len(columns) >= len(expected.columns) && reflect.DeepEqual(columns[:len(expected.columns)], expected.columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameColumns mengecek apakah a dan b berisi kolom yang sama tanpa memperhatikan urutan.
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return reflect.DeepEqual(a, b)
+}
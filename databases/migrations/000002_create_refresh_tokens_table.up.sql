@@ -0,0 +1,14 @@
+CREATE TABLE refresh_tokens (
+    internal_id BIGSERIAL PRIMARY KEY,
+    family_id UUID NOT NULL,
+    user_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    token_hash varchar(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    replaced_by_internal_id BIGINT NULL REFERENCES refresh_tokens (internal_id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT refresh_token_hash_unique UNIQUE (token_hash)
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE INDEX idx_refresh_tokens_user_internal_id ON refresh_tokens (user_internal_id);
@@ -0,0 +1,18 @@
+CREATE TABLE revoked_tokens (
+    jti UUID PRIMARY KEY,
+    user_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_revoked_tokens_user_internal_id ON revoked_tokens (user_internal_id);
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+CREATE INDEX idx_revoked_tokens_revoked_at ON revoked_tokens (revoked_at);
+
+CREATE TABLE user_token_revocations (
+    user_internal_id BIGINT PRIMARY KEY REFERENCES users (internal_id) ON DELETE CASCADE,
+    revoked_before TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_token_revocations_updated_at ON user_token_revocations (updated_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS list_positions;
+DROP TABLE IF EXISTS board_members;
+DROP TABLE IF EXISTS boards;
@@ -0,0 +1,32 @@
+CREATE TABLE boards (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    title varchar(255) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    owner_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    owner_public_id UUID NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    due_date TIMESTAMPTZ NULL,
+    CONSTRAINT board_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_boards_owner_internal_id ON boards (owner_internal_id);
+
+CREATE TABLE board_members (
+    board_internal_id BIGINT NOT NULL REFERENCES boards (internal_id) ON DELETE CASCADE,
+    user_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    joined_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (board_internal_id, user_internal_id)
+);
+
+CREATE INDEX idx_board_members_user_internal_id ON board_members (user_internal_id);
+
+CREATE TABLE list_positions (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    board_id BIGINT NOT NULL REFERENCES boards (internal_id) ON DELETE CASCADE,
+    list_order UUID[] NOT NULL DEFAULT '{}',
+    CONSTRAINT list_position_public_id_unique UNIQUE (public_id),
+    CONSTRAINT list_position_board_id_unique UNIQUE (board_id)
+);
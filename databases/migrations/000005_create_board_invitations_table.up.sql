@@ -0,0 +1,19 @@
+CREATE TABLE board_invitations (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    board_internal_id BIGINT NOT NULL REFERENCES boards (internal_id) ON DELETE CASCADE,
+    board_public_id UUID NOT NULL,
+    email varchar(255) NOT NULL,
+    invited_by_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    token_hash varchar(64) NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT board_invitation_public_id_unique UNIQUE (public_id),
+    CONSTRAINT board_invitation_token_hash_unique UNIQUE (token_hash),
+    CONSTRAINT board_invitation_status_check CHECK (status IN ('pending', 'accepted', 'declined', 'revoked'))
+);
+
+CREATE INDEX idx_board_invitations_board_internal_id ON board_invitations (board_internal_id);
+CREATE INDEX idx_board_invitations_email ON board_invitations (email);
@@ -0,0 +1,14 @@
+ALTER TABLE board_members
+    ADD COLUMN role varchar(20) NOT NULL DEFAULT 'editor',
+    ADD CONSTRAINT board_member_role_check CHECK (role IN ('owner', 'admin', 'editor', 'commenter', 'viewer'));
+
+-- Pembuat board yang sudah ada otomatis menjadi owner.
+UPDATE board_members bm
+SET role = 'owner'
+FROM boards b
+WHERE b.internal_id = bm.board_internal_id
+  AND b.owner_internal_id = bm.user_internal_id;
+
+ALTER TABLE board_invitations
+    ADD COLUMN role varchar(20) NOT NULL DEFAULT 'editor',
+    ADD CONSTRAINT board_invitation_role_check CHECK (role IN ('admin', 'editor', 'commenter', 'viewer'));
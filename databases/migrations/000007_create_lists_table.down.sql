@@ -0,0 +1 @@
+DROP TABLE IF EXISTS lists;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE lists (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    board_public_id UUID NOT NULL,
+    title varchar(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    board_internal_id BIGINT NOT NULL REFERENCES boards (internal_id) ON DELETE CASCADE,
+    CONSTRAINT list_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_lists_board_internal_id ON lists (board_internal_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS card_positions;
+DROP TABLE IF EXISTS cards;
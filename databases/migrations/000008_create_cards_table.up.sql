@@ -0,0 +1,24 @@
+CREATE TABLE cards (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    list_id BIGINT NOT NULL REFERENCES lists (internal_id) ON DELETE CASCADE,
+    list_public_id UUID NOT NULL,
+    title varchar(255) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    due_date TIMESTAMPTZ NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT card_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_cards_list_id ON cards (list_id);
+
+CREATE TABLE card_positions (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    list_internal_id BIGINT NOT NULL REFERENCES lists (internal_id) ON DELETE CASCADE,
+    card_order UUID[] NOT NULL DEFAULT '{}',
+    CONSTRAINT card_position_public_id_unique UNIQUE (public_id),
+    CONSTRAINT card_position_list_internal_id_unique UNIQUE (list_internal_id)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cards_list_id_rank;
+DROP INDEX IF EXISTS idx_lists_board_internal_id_rank;
+ALTER TABLE cards DROP COLUMN IF EXISTS rank;
+ALTER TABLE lists DROP COLUMN IF EXISTS rank;
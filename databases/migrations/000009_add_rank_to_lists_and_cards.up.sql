@@ -0,0 +1,13 @@
+-- Kolom rank dipakai jika ORDERING_STRATEGY=rank.
+-- COLLATE "C" memastikan perbandingan string byte-per-byte (sama dengan perbandingan di Go),
+-- bukan mengikuti collation bahasa yang bisa mengurutkan "a" dan "B" secara berbeda.
+--
+-- Baris lama dibiarkan kosong (''): saat server berjalan dengan ORDERING_STRATEGY=rank,
+-- rank rebalancer mengisinya dari ListPosition.list_order dan CardPosition.card_order.
+ALTER TABLE lists ADD COLUMN rank TEXT COLLATE "C" NOT NULL DEFAULT '';
+
+ALTER TABLE cards ADD COLUMN rank TEXT COLLATE "C" NOT NULL DEFAULT '';
+
+CREATE INDEX idx_lists_board_internal_id_rank ON lists (board_internal_id, rank);
+
+CREATE INDEX idx_cards_list_id_rank ON cards (list_id, rank);
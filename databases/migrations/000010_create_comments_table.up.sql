@@ -0,0 +1,41 @@
+CREATE TABLE comments (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    card_internal_id BIGINT NOT NULL REFERENCES cards (internal_id) ON DELETE CASCADE,
+    card_public_id UUID NOT NULL,
+    user_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    user_public_id UUID NOT NULL,
+    parent_internal_id BIGINT NULL REFERENCES comments (internal_id) ON DELETE CASCADE,
+    parent_public_id UUID NULL,
+    message text NOT NULL,
+    edited_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ NULL,
+    CONSTRAINT comment_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_comments_card_internal_id ON comments (card_internal_id);
+CREATE INDEX idx_comments_parent_internal_id ON comments (parent_internal_id);
+CREATE INDEX idx_comments_deleted_at ON comments (deleted_at);
+
+CREATE TABLE comment_revisions (
+    internal_id BIGSERIAL PRIMARY KEY,
+    comment_internal_id BIGINT NOT NULL REFERENCES comments (internal_id) ON DELETE CASCADE,
+    message text NOT NULL,
+    edited_by_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    edited_by_public_id UUID NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_comment_revisions_comment_internal_id ON comment_revisions (comment_internal_id);
+
+CREATE TABLE comment_mentions (
+    comment_internal_id BIGINT NOT NULL REFERENCES comments (internal_id) ON DELETE CASCADE,
+    user_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    user_public_id UUID NOT NULL,
+    handle varchar(255) NOT NULL,
+    PRIMARY KEY (comment_internal_id, user_internal_id)
+);
+
+CREATE INDEX idx_comment_mentions_user_internal_id ON comment_mentions (user_internal_id);
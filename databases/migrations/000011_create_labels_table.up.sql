@@ -0,0 +1,20 @@
+CREATE TABLE labels (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    board_internal_id BIGINT NOT NULL REFERENCES boards (internal_id) ON DELETE CASCADE,
+    board_public_id UUID NOT NULL,
+    name varchar(255) NOT NULL DEFAULT '',
+    color varchar(32) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT label_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_labels_board_internal_id ON labels (board_internal_id);
+
+CREATE TABLE card_labels (
+    card_internal_id BIGINT NOT NULL REFERENCES cards (internal_id) ON DELETE CASCADE,
+    label_internal_id BIGINT NOT NULL REFERENCES labels (internal_id) ON DELETE CASCADE,
+    PRIMARY KEY (card_internal_id, label_internal_id)
+);
+
+CREATE INDEX idx_card_labels_label_internal_id ON card_labels (label_internal_id);
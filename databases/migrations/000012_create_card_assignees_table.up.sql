@@ -0,0 +1,8 @@
+CREATE TABLE card_assignees (
+    card_internal_id BIGINT NOT NULL REFERENCES cards (internal_id) ON DELETE CASCADE,
+    user_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    assigned_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (card_internal_id, user_internal_id)
+);
+
+CREATE INDEX idx_card_assignees_user_internal_id ON card_assignees (user_internal_id);
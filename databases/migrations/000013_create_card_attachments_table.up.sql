@@ -0,0 +1,16 @@
+CREATE TABLE card_attachments (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    file varchar(512) NOT NULL,
+    file_name varchar(255) NOT NULL,
+    content_type varchar(255) NOT NULL,
+    size BIGINT NOT NULL,
+    user_internal_id BIGINT NOT NULL REFERENCES users (internal_id) ON DELETE CASCADE,
+    user_public_id UUID NOT NULL,
+    card_internal_id BIGINT NOT NULL REFERENCES cards (internal_id) ON DELETE CASCADE,
+    card_public_id UUID NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT card_attachment_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_card_attachments_card_internal_id ON card_attachments (card_internal_id);
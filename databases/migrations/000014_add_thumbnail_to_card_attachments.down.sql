@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_card_attachments_thumbnail_pending;
+ALTER TABLE card_attachments DROP COLUMN IF EXISTS thumbnail_status;
+ALTER TABLE card_attachments DROP COLUMN IF EXISTS thumbnail_key;
+ALTER TABLE card_attachments DROP COLUMN IF EXISTS height;
+ALTER TABLE card_attachments DROP COLUMN IF EXISTS width;
@@ -0,0 +1,14 @@
+-- Metadata gambar & thumbnail attachment. Thumbnail dibuat di background oleh thumbnail worker:
+-- attachment gambar disimpan dengan thumbnail_status 'pending', lalu worker mengisinya
+-- menjadi 'ready' (atau 'failed' jika gambar tidak bisa dibaca).
+-- Attachment yang bukan gambar memiliki thumbnail_status kosong ('').
+ALTER TABLE card_attachments ADD COLUMN width INT;
+
+ALTER TABLE card_attachments ADD COLUMN height INT;
+
+ALTER TABLE card_attachments ADD COLUMN thumbnail_key varchar(512) NOT NULL DEFAULT '';
+
+ALTER TABLE card_attachments ADD COLUMN thumbnail_status varchar(20) NOT NULL DEFAULT '';
+
+-- Partial index: worker hanya mencari baris 'pending', yang jumlahnya selalu sedikit.
+CREATE INDEX idx_card_attachments_thumbnail_pending ON card_attachments (internal_id) WHERE thumbnail_status = 'pending';
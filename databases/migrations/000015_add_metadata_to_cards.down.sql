@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_cards_cover_attachment_internal_id;
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS card_cover_attachment_fk;
+ALTER TABLE card_attachments DROP CONSTRAINT IF EXISTS card_attachment_internal_id_public_id_unique;
+ALTER TABLE cards DROP COLUMN IF EXISTS cover_attachment_public_id;
+ALTER TABLE cards DROP COLUMN IF EXISTS cover_attachment_internal_id;
+ALTER TABLE cards DROP COLUMN IF EXISTS estimate;
+ALTER TABLE cards DROP COLUMN IF EXISTS priority;
+ALTER TABLE cards DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE cards DROP COLUMN IF EXISTS due_complete;
+ALTER TABLE cards DROP COLUMN IF EXISTS start_date;
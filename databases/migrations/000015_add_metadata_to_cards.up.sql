@@ -0,0 +1,34 @@
+ALTER TABLE cards ADD COLUMN start_date TIMESTAMPTZ NULL;
+
+-- due_complete menandai kartu sudah selesai; completed_at diisi saat kartu ditandai selesai.
+ALTER TABLE cards ADD COLUMN due_complete BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE cards ADD COLUMN completed_at TIMESTAMPTZ NULL;
+
+ALTER TABLE cards ADD COLUMN priority varchar(10) NOT NULL DEFAULT '';
+
+ALTER TABLE cards ADD COLUMN estimate INTEGER NULL;
+
+ALTER TABLE cards ADD COLUMN cover_attachment_internal_id BIGINT NULL;
+
+ALTER TABLE cards ADD COLUMN cover_attachment_public_id UUID NULL;
+
+ALTER TABLE cards
+    ADD CONSTRAINT card_priority_check CHECK (priority IN ('', 'low', 'medium', 'high', 'urgent'));
+
+ALTER TABLE cards
+    ADD CONSTRAINT card_estimate_check CHECK (estimate >= 0);
+
+ALTER TABLE cards
+    ADD CONSTRAINT card_start_before_due_check CHECK (start_date IS NULL OR due_date IS NULL OR start_date <= due_date);
+
+-- Foreign key cover memakai dua kolom (internal_id, public_id) agar saat attachment dihapus,
+-- ON DELETE SET NULL mengosongkan KEDUA kolom cover sekaligus.
+ALTER TABLE card_attachments
+    ADD CONSTRAINT card_attachment_internal_id_public_id_unique UNIQUE (internal_id, public_id);
+
+ALTER TABLE cards
+    ADD CONSTRAINT card_cover_attachment_fk FOREIGN KEY (cover_attachment_internal_id, cover_attachment_public_id)
+    REFERENCES card_attachments (internal_id, public_id) ON DELETE SET NULL;
+
+CREATE INDEX idx_cards_cover_attachment_internal_id ON cards (cover_attachment_internal_id);
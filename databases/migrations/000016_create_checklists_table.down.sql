@@ -0,0 +1,5 @@
+ALTER TABLE cards DROP COLUMN IF EXISTS checklist_done;
+ALTER TABLE cards DROP COLUMN IF EXISTS checklist_total;
+DROP TABLE IF EXISTS checklist_items;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS user_internal_id_public_id_unique;
+DROP TABLE IF EXISTS checklists;
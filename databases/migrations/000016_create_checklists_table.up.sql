@@ -0,0 +1,53 @@
+-- Urutan checklist di kartu dan item di checklist memakai kolom rank (fractional index),
+-- sama seperti ORDERING_STRATEGY=rank untuk list & kartu. Lihat utils.RankBetween.
+CREATE TABLE checklists (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    card_internal_id BIGINT NOT NULL REFERENCES cards (internal_id) ON DELETE CASCADE,
+    card_public_id UUID NOT NULL,
+    title varchar(255) NOT NULL,
+    rank TEXT COLLATE "C" NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT checklist_public_id_unique UNIQUE (public_id)
+);
+
+CREATE INDEX idx_checklists_card_internal_id_rank ON checklists (card_internal_id, rank);
+
+-- Foreign key assignee memakai dua kolom (internal_id, public_id) agar saat user dihapus,
+-- ON DELETE SET NULL mengosongkan KEDUA kolom assignee sekaligus.
+ALTER TABLE users
+    ADD CONSTRAINT user_internal_id_public_id_unique UNIQUE (internal_id, public_id);
+
+CREATE TABLE checklist_items (
+    internal_id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid (),
+    checklist_internal_id BIGINT NOT NULL REFERENCES checklists (internal_id) ON DELETE CASCADE,
+    checklist_public_id UUID NOT NULL,
+    -- card_internal_id disalin dari checklist agar progress kartu bisa dihitung tanpa JOIN.
+    card_internal_id BIGINT NOT NULL REFERENCES cards (internal_id) ON DELETE CASCADE,
+    title varchar(255) NOT NULL,
+    rank TEXT COLLATE "C" NOT NULL,
+    checked BOOLEAN NOT NULL DEFAULT false,
+    checked_at TIMESTAMPTZ NULL,
+    assignee_internal_id BIGINT NULL,
+    assignee_public_id UUID NULL,
+    due_date TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT checklist_item_public_id_unique UNIQUE (public_id),
+    CONSTRAINT checklist_item_assignee_fk FOREIGN KEY (assignee_internal_id, assignee_public_id)
+        REFERENCES users (internal_id, public_id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_checklist_items_checklist_internal_id_rank ON checklist_items (checklist_internal_id, rank);
+
+CREATE INDEX idx_checklist_items_card_internal_id ON checklist_items (card_internal_id);
+
+CREATE INDEX idx_checklist_items_assignee_internal_id ON checklist_items (assignee_internal_id);
+
+-- Progress checklist disimpan di kartu agar ikut terbaca di setiap response kartu
+-- tanpa query tambahan. Nilainya dihitung ulang setiap kali checklist/item berubah.
+ALTER TABLE cards ADD COLUMN checklist_total INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE cards ADD COLUMN checklist_done INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_card_attachments_user_internal_id;
+DROP INDEX IF EXISTS idx_comment_revisions_edited_by_internal_id;
+DROP INDEX IF EXISTS idx_comments_user_internal_id;
+DROP INDEX IF EXISTS idx_board_invitations_invited_by_internal_id;
+DROP INDEX IF EXISTS idx_refresh_tokens_replaced_by_internal_id;
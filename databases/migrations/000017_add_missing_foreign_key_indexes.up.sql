@@ -0,0 +1,11 @@
+-- Index untuk kolom foreign key yang belum punya index. Tanpa index ini, menghapus user
+-- (ON DELETE CASCADE / SET NULL) memaksa PostgreSQL men-scan seluruh tabel anak.
+CREATE INDEX idx_refresh_tokens_replaced_by_internal_id ON refresh_tokens (replaced_by_internal_id);
+
+CREATE INDEX idx_board_invitations_invited_by_internal_id ON board_invitations (invited_by_internal_id);
+
+CREATE INDEX idx_comments_user_internal_id ON comments (user_internal_id);
+
+CREATE INDEX idx_comment_revisions_edited_by_internal_id ON comment_revisions (edited_by_internal_id);
+
+CREATE INDEX idx_card_attachments_user_internal_id ON card_attachments (user_internal_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_deleted_at;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS user_email_unique;
+ALTER TABLE users ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at::timestamp;
@@ -0,0 +1,10 @@
+-- 000001 membuat deleted_at sebagai TIMESTAMP (tanpa zona waktu), padahal kolom waktu lain
+-- memakai TIMESTAMPTZ. Nilai lama dikonversi mengikuti setting TimeZone sesi yang menjalankan
+-- migration ini; samakan dengan zona waktu server aplikasi jika keduanya berbeda.
+ALTER TABLE users ALTER COLUMN deleted_at TYPE TIMESTAMPTZ USING deleted_at::timestamptz;
+
+-- models.User menandai email dengan gorm:"unique", tapi constraint-nya belum ada di database.
+-- Jika migration ini gagal, berarti sudah ada email ganda yang harus dirapikan lebih dulu.
+ALTER TABLE users ADD CONSTRAINT user_email_unique UNIQUE (email);
+
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
@@ -0,0 +1,379 @@
+// Package databases berisi skema database (file SQL di folder migrations) dan
+// Migrator untuk menjalankannya.
+//
+// File migration ikut di-embed ke binary (go:embed), sehingga perintah migrate
+// bisa dijalankan di server production tanpa menyalin folder migrations.
+//
+// Format nama file mengikuti golang-migrate: {versi}_{nama}.up.sql dan {versi}_{nama}.down.sql.
+// Versi yang sedang terpasang disimpan di tabel schema_migrations (juga format golang-migrate),
+// jadi database yang sebelumnya di-migrate dengan CLI golang-migrate tetap bisa dilanjutkan.
+package databases
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey adalah key pg_advisory_lock untuk migration. Nilainya bebas,
+// asal sama di semua instance dan tidak dipakai advisory lock lain.
+const migrationLockKey int64 = 7_316_420_190
+
+// migrationFilePattern menangkap versi, nama, dan arah dari nama file migration.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// ErrDirty dikembalikan jika migration terakhir gagal di tengah jalan (dirty = true).
+// Periksa database secara manual, lalu jalankan "migrate force VERSI".
+var ErrDirty = errors.New("database is dirty, fix it manually and run \"migrate force VERSION\"")
+
+// Migration adalah satu versi skema beserta SQL untuk naik (Up) dan turun (Down).
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus adalah keadaan skema database saat ini.
+type MigrationStatus struct {
+	Version uint64 // 0 berarti belum ada migration yang dijalankan
+	Dirty   bool
+	Latest  uint64      // Versi migration terbaru yang tersedia
+	Pending []Migration // Migration yang belum dijalankan
+}
+
+// LoadMigrations membaca semua file migration yang di-embed, diurutkan berdasarkan versi.
+func LoadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(migrationFiles, path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator menjalankan migration ke database PostgreSQL.
+//
+// Setiap perintah mengambil pg_advisory_lock lebih dulu, sehingga jika dua instance
+// menjalankan migrate bersamaan (misal saat deploy beberapa pod), yang kedua menunggu
+// sampai yang pertama selesai, bukan menjalankan migration yang sama dua kali.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator membuat Migrator untuk db dengan migration yang di-embed.
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Status mengembalikan versi skema saat ini dan migration yang belum dijalankan.
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	var status *MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		status = m.newStatus(version, dirty)
+		return nil
+	})
+	return status, err
+}
+
+// Peek sama seperti Status, tapi tanpa advisory lock dan tanpa membuat tabel schema_migrations.
+// Dipakai untuk pengecekan yang sering dan hanya membaca, seperti readiness probe
+// (GET /readyz), agar tidak ikut antre di belakang migration yang sedang berjalan.
+func (m *Migrator) Peek(ctx context.Context) (*MigrationStatus, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return m.newStatus(0, false), nil
+	}
+
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	return m.newStatus(version, dirty), nil
+}
+
+// newStatus membuat MigrationStatus untuk versi database saat ini.
+func (m *Migrator) newStatus(version uint64, dirty bool) *MigrationStatus {
+	status := &MigrationStatus{Version: version, Dirty: dirty, Latest: m.latest()}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status
+}
+
+// Up menjalankan migration yang belum dijalankan. steps <= 0 berarti semua.
+// Mengembalikan migration yang berhasil dijalankan.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w (current version %d)", ErrDirty, version)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			if steps > 0 && len(applied) == steps {
+				break
+			}
+			if err := runMigration(ctx, conn, migration.Up, migration.Version, version); err != nil {
+				return fmt.Errorf("migration %d_%s up failed: %w", migration.Version, migration.Name, err)
+			}
+			version = migration.Version
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down membatalkan steps migration terakhir. steps <= 0 berarti semua (skema kosong).
+// Mengembalikan migration yang berhasil dibatalkan.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w (current version %d)", ErrDirty, version)
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version > version {
+				continue
+			}
+			if steps > 0 && len(reverted) == steps {
+				break
+			}
+
+			var previous uint64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+			if err := runMigration(ctx, conn, migration.Down, previous, version); err != nil {
+				return fmt.Errorf("migration %d_%s down failed: %w", migration.Version, migration.Name, err)
+			}
+			version = previous
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Force menyetel versi skema tanpa menjalankan SQL apa pun dan menghapus tanda dirty.
+// Dipakai setelah memperbaiki database secara manual. version 0 berarti "belum ada migration".
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version != 0 && !m.exists(version) {
+		return fmt.Errorf("migration version %d does not exist", version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := writeVersion(ctx, tx, version, false); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// withLock menjalankan fn dengan pg_advisory_lock di satu koneksi yang sama.
+// Advisory lock berlaku per koneksi (session), jadi semua query harus lewat conn.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// DB_STATEMENT_TIMEOUT dimaksudkan untuk query request, bukan migration: menunggu lock dan
+	// membuat index di tabel besar bisa lama. RESET mengembalikan nilai awal koneksi sebelum
+	// koneksi kembali ke pool.
+	if _, err := conn.ExecContext(ctx, "SET statement_timeout = 0"); err != nil {
+		return fmt.Errorf("disable statement timeout: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "RESET statement_timeout")
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	// Unlock memakai context.Background() agar tetap dijalankan walaupun ctx sudah dibatalkan.
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		dirty BOOLEAN NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create schema_migrations table: %w", err)
+	}
+	return fn(conn)
+}
+
+// latest mengembalikan versi migration terbaru yang tersedia.
+func (m *Migrator) latest() uint64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// exists mengecek apakah ada migration dengan versi tersebut.
+func (m *Migrator) exists(version uint64) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// runMigration menjalankan SQL migration dan mencatat versi barunya dalam SATU transaksi.
+//
+// DDL PostgreSQL bersifat transaksional: jika SQL gagal, semua perubahannya dibatalkan
+// dan versi tetap di from. Sebelum transaksi dimulai, versi ditandai dirty; tanda ini
+// hanya tertinggal jika proses mati di tengah jalan, sama seperti perilaku golang-migrate.
+func runMigration(ctx context.Context, conn *sql.Conn, query string, to, from uint64) error {
+	if err := setVersion(ctx, conn, from, true); err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		tx.Rollback()
+		// SQL gagal tapi sudah di-rollback: skema masih utuh di versi from.
+		if cleanErr := setVersion(ctx, conn, from, false); cleanErr != nil {
+			return errors.Join(err, cleanErr)
+		}
+		return err
+	}
+	if err := writeVersion(ctx, tx, to, false); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// readVersion membaca versi dan tanda dirty dari schema_migrations.
+// Tabel kosong berarti versi 0 (belum ada migration).
+func readVersion(ctx context.Context, conn *sql.Conn) (uint64, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint64(version), dirty, nil
+}
+
+// setVersion menulis versi dalam transaksinya sendiri.
+func setVersion(ctx context.Context, conn *sql.Conn, version uint64, dirty bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := writeVersion(ctx, tx, version, dirty); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// writeVersion mengganti isi schema_migrations. Seperti golang-migrate, tabel ini
+// hanya berisi satu baris; versi 0 yang bersih disimpan sebagai tabel kosong.
+func writeVersion(ctx context.Context, tx *sql.Tx, version uint64, dirty bool) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if version == 0 && !dirty {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)", int64(version), dirty)
+	return err
+}
@@ -0,0 +1,202 @@
+// Package seed berisi seeder untuk mengisi data awal ke database: akun admin pertama,
+// data demo untuk mencoba aplikasi, dan dataset sintetis besar untuk load testing.
+//
+// Setiap seeder punya nama dan aman dijalankan berkali-kali (idempotent): data yang sudah
+// ada dilewati, bukan dibuat ganda. Seeder dijalankan lewat CLI:
+//
+//	go run ./cmd/manage seed              # seeder default (admin, demo; di production hanya admin)
+//	go run ./cmd/manage seed load --scale 500
+//	go run ./cmd/manage seed --list
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/models/types"
+	"gorm.io/gorm"
+)
+
+// ErrDefaultCredentials dikembalikan jika seeder akan membuat akun dengan kredensial
+// yang sudah diketahui publik (default) saat aplikasi berjalan di production.
+var ErrDefaultCredentials = errors.New("refusing to seed default credentials in production")
+
+// Options adalah parameter untuk semua seeder.
+type Options struct {
+	// Production bernilai true jika APP_ENV=production.
+	Production bool
+
+	// Akun admin untuk seeder "admin".
+	AdminName     string
+	AdminEmail    string
+	AdminPassword string
+
+	// LoadScale adalah jumlah user sintetis (masing-masing dengan satu board) untuk seeder "load".
+	LoadScale int
+}
+
+// Seeder adalah satu langkah seeding yang bisa dipilih berdasarkan namanya.
+type Seeder struct {
+	Name        string
+	Description string
+
+	// Default berarti seeder ikut dijalankan saat "seed" dipanggil tanpa nama seeder.
+	Default bool
+
+	// DefaultCredentials berarti seeder membuat user dengan password yang sudah diketahui,
+	// sehingga tidak boleh dijalankan di production.
+	DefaultCredentials bool
+
+	Run func(ctx context.Context, db *gorm.DB, opts Options) error
+}
+
+// All mengembalikan semua seeder, dalam urutan yang aman untuk dijalankan berurutan.
+func All() []Seeder {
+	return []Seeder{adminSeeder, demoSeeder, loadSeeder}
+}
+
+// Run menjalankan seeder dengan nama names secara berurutan. Tanpa names, semua seeder
+// Default yang dijalankan. Nama yang tidak dikenal ditolak sebelum seeder apa pun berjalan.
+//
+// Di production, seeder DefaultCredentials dilewati dari pilihan default (dengan log),
+// tapi jika operator menyebut namanya secara eksplisit, Run menolak dengan ErrDefaultCredentials.
+func Run(ctx context.Context, db *gorm.DB, names []string, opts Options) error {
+	seeders, err := selectSeeders(names, opts.Production)
+	if err != nil {
+		return err
+	}
+
+	for _, seeder := range seeders {
+		start := time.Now()
+		log.Printf("Seeding %s...", seeder.Name)
+		if err := seeder.Run(ctx, db.WithContext(ctx), opts); err != nil {
+			return fmt.Errorf("seeder %q failed: %w", seeder.Name, err)
+		}
+		log.Printf("Seeded %s in %s", seeder.Name, time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+// selectSeeders mencari seeder berdasarkan nama, dengan urutan mengikuti All(). Seleksi
+// (termasuk cek production) selesai sebelum seeder apa pun berjalan, jadi tidak ada seeder
+// yang terlanjur berjalan jika pilihannya ditolak.
+func selectSeeders(names []string, production bool) ([]Seeder, error) {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	var selected []Seeder
+	for _, seeder := range All() {
+		explicit := wanted[seeder.Name]
+		if !explicit && (len(names) > 0 || !seeder.Default) {
+			continue
+		}
+		delete(wanted, seeder.Name)
+
+		if production && seeder.DefaultCredentials {
+			if explicit {
+				return nil, fmt.Errorf("%w: seeder %q creates users with a known password", ErrDefaultCredentials, seeder.Name)
+			}
+			log.Printf("Skipping %s: it creates users with a known password and APP_ENV is production", seeder.Name)
+			continue
+		}
+		selected = append(selected, seeder)
+	}
+	for name := range wanted {
+		return nil, fmt.Errorf("unknown seeder %q", name)
+	}
+	return selected, nil
+}
+
+// ============================================================================
+// HELPER
+// ============================================================================
+// Helper di bawah ini menulis langsung ke tabel dengan format yang sama seperti repositories
+// (strategi urutan "array": ListPosition.ListOrder, CardPosition.CardOrder, dan Card.Position).
+// Kolom rank sengaja dibiarkan kosong: jika server berjalan dengan ORDERING_STRATEGY=rank,
+// rank rebalancer mengisinya dari array saat start.
+
+// findOrCreateUser mencari user berdasarkan email, atau membuatnya jika belum ada.
+// User yang sudah ada tidak diubah (password-nya tidak ditimpa).
+func findOrCreateUser(tx *gorm.DB, user *models.User) error {
+	return tx.Where(models.User{Email: user.Email}).FirstOrCreate(user).Error
+}
+
+// createBoard membuat board milik owner beserta ListPosition kosong dan keanggotaannya.
+// members berisi user lain yang ikut menjadi member board beserta role-nya.
+func createBoard(tx *gorm.DB, board *models.Board, owner *models.User, members map[*models.User]string) error {
+	board.OwnerID = owner.InternalID
+	board.OwnerPublicID = owner.PublicID
+	if err := tx.Create(board).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	boardMembers := []models.BoardMember{{BoardID: board.InternalID, UserID: owner.InternalID, Role: models.BoardRoleOwner, JoinedAt: now}}
+	for user, role := range members {
+		if user.InternalID == owner.InternalID {
+			continue
+		}
+		boardMembers = append(boardMembers, models.BoardMember{BoardID: board.InternalID, UserID: user.InternalID, Role: role, JoinedAt: now})
+	}
+	if err := tx.Create(&boardMembers).Error; err != nil {
+		return err
+	}
+	return tx.Create(&models.ListPosition{BoardID: board.InternalID}).Error
+}
+
+// createLists membuat list berurutan di board beserta CardPosition kosongnya.
+func createLists(tx *gorm.DB, board *models.Board, titles []string) ([]models.List, error) {
+	lists := make([]models.List, len(titles))
+	order := make(types.UUIDArray, len(titles))
+	for i, title := range titles {
+		lists[i] = models.List{BoardInternalID: board.InternalID, BoardPublicID: board.PublicID, Title: title}
+	}
+	if err := tx.Create(&lists).Error; err != nil {
+		return nil, err
+	}
+
+	positions := make([]models.CardPosition, len(lists))
+	for i := range lists {
+		order[i] = lists[i].PublicID
+		positions[i] = models.CardPosition{ListID: lists[i].InternalID}
+	}
+	if err := tx.Create(&positions).Error; err != nil {
+		return nil, err
+	}
+	return lists, tx.Model(&models.ListPosition{}).
+		Where("board_id = ?", board.InternalID).
+		Update("list_order", order).Error
+}
+
+// createCards menyimpan cards di akhir list sesuai urutan slice.
+func createCards(tx *gorm.DB, list *models.List, cards []models.Card) error {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	var position models.CardPosition
+	if err := tx.Where("list_internal_id = ?", list.InternalID).First(&position).Error; err != nil {
+		return err
+	}
+
+	for i := range cards {
+		cards[i].ListID = list.InternalID
+		cards[i].ListPublicID = list.PublicID
+		cards[i].Position = len(position.CardOrder) + i
+	}
+	if err := tx.CreateInBatches(&cards, 500).Error; err != nil {
+		return err
+	}
+
+	for i := range cards {
+		position.CardOrder = append(position.CardOrder, cards[i].PublicId)
+	}
+	return tx.Model(&position).Update("card_order", position.CardOrder).Error
+}
@@ -1,42 +1,53 @@
-// Package seed berisi fungsi-fungsi untuk mengisi data awal (seed) ke database.
-// Seeding berguna untuk membuat data default seperti admin pertama atau data testing.
 package seed
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"strings"
 
-	"github.com/rakafajars/go-manajemen-project/config"
 	"github.com/rakafajars/go-manajemen-project/models"
 	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
 )
 
-// SeedAdmin membuat akun admin pertama di database.
-// Fungsi ini biasanya dipanggil sekali saat aplikasi pertama kali dijalankan.
-func SeedAdmin() {
-	// 1. Hash password "admin" menggunakan bcrypt.
-	// Kita TIDAK BOLEH menyimpan password plain text di database!
-	// Underscore (_) mengabaikan error, tapi di production sebaiknya di-handle.
-	password, _ := utils.HashPassword("admin")
+// Kredensial admin bawaan (lihat SEED_ADMIN_EMAIL & SEED_ADMIN_PASSWORD di config).
+// Hanya boleh dipakai di development.
+const (
+	DefaultAdminEmail    = "admin@admin.com"
+	DefaultAdminPassword = "admin"
+)
 
-	// 2. Buat objek User dengan data admin.
-	admin := models.User{
-		Name:     "Admin",
-		Email:    "admin@admin.com",
-		Password: password, // Password yang sudah di-hash
-		Role:     "admin",
+// adminSeeder membuat akun admin pertama dari Options.AdminName/AdminEmail/AdminPassword.
+var adminSeeder = Seeder{
+	Name:        "admin",
+	Description: "admin account from SEED_ADMIN_NAME, SEED_ADMIN_EMAIL and SEED_ADMIN_PASSWORD",
+	Default:     true,
+	Run:         seedAdmin,
+}
+
+// seedAdmin membuat akun admin. Jika email sudah terdaftar, akun tersebut dibiarkan apa adanya
+// (password tidak ditimpa), sehingga seeder ini aman dijalankan setiap kali deploy.
+func seedAdmin(ctx context.Context, db *gorm.DB, opts Options) error {
+	// Email disimpan huruf kecil, sama seperti saat register (services.normalizeEmail).
+	email := strings.ToLower(strings.TrimSpace(opts.AdminEmail))
+	if email == "" || opts.AdminPassword == "" {
+		return fmt.Errorf("admin email and password must not be empty: set SEED_ADMIN_EMAIL and SEED_ADMIN_PASSWORD")
+	}
+	if opts.Production && (email == DefaultAdminEmail || opts.AdminPassword == DefaultAdminPassword) {
+		return fmt.Errorf("%w: set SEED_ADMIN_EMAIL and SEED_ADMIN_PASSWORD", ErrDefaultCredentials)
+	}
+
+	// Password di-hash dengan bcrypt. Kita TIDAK BOLEH menyimpan password plain text di database!
+	password, err := utils.HashPassword(opts.AdminPassword)
+	if err != nil {
+		return fmt.Errorf("hash admin password: %w", err)
 	}
 
-	// 3. Simpan ke database menggunakan FirstOrCreate.
-	//
-	// FirstOrCreate adalah fitur GORM yang sangat berguna:
-	// - Pertama, GORM akan MENCARI user dengan Email = "admin@admin.com".
-	// - Jika DITEMUKAN: Tidak melakukan apa-apa (menghindari duplikasi).
-	// - Jika TIDAK DITEMUKAN: Buat user baru dengan data dari variabel `admin`.
-	//
-	// Ini memastikan fungsi ini bisa dipanggil berkali-kali tanpa membuat duplikat.
-	if err := config.DB.FirstOrCreate(&admin, models.User{Email: admin.Email}).Error; err != nil {
-		log.Println("Failed to seed admin:", err)
-	} else {
-		log.Println("Admin seeded successfully")
+	admin := models.User{
+		Name:     opts.AdminName,
+		Email:    email,
+		Password: password,
+		Role:     "admin",
 	}
+	return findOrCreateUser(db, &admin)
 }
@@ -0,0 +1,153 @@
+package seed
+
+import (
+	"context"
+	"time"
+
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// DemoPassword adalah password semua user demo (alice, bob, carol @demo.test).
+const DemoPassword = "demo1234"
+
+// demoBoardTitle dipakai untuk mengecek apakah data demo sudah pernah dibuat.
+const demoBoardTitle = "Website Redesign"
+
+// demoSeeder membuat beberapa user, satu board lengkap dengan list, label, kartu, dan assignee,
+// agar aplikasi bisa langsung dicoba tanpa membuat data satu per satu.
+var demoSeeder = Seeder{
+	Name:               "demo",
+	Description:        "demo users (password " + DemoPassword + ") with a board, lists, labels and cards",
+	Default:            true,
+	DefaultCredentials: true,
+	Run:                seedDemo,
+}
+
+// demoCard adalah kartu demo beserta nama label dan assignee-nya.
+type demoCard struct {
+	Title     string
+	Priority  string
+	DueInDays int // 0 berarti tanpa due date; negatif berarti sudah lewat
+	Labels    []string
+	Assignees []*models.User
+}
+
+func seedDemo(ctx context.Context, db *gorm.DB, opts Options) error {
+	password, err := utils.HashPassword(DemoPassword)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		alice := &models.User{Name: "Alice", Email: "alice@demo.test", Password: password, Role: "user"}
+		bob := &models.User{Name: "Bob", Email: "bob@demo.test", Password: password, Role: "user"}
+		carol := &models.User{Name: "Carol", Email: "carol@demo.test", Password: password, Role: "user"}
+		for _, user := range []*models.User{alice, bob, carol} {
+			if err := findOrCreateUser(tx, user); err != nil {
+				return err
+			}
+		}
+
+		var existing int64
+		if err := tx.Model(&models.Board{}).
+			Where("owner_internal_id = ? AND title = ?", alice.InternalID, demoBoardTitle).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return nil
+		}
+
+		board := &models.Board{Title: demoBoardTitle, Description: "Redesign of the company website, planned together with the marketing team."}
+		if err := createBoard(tx, board, alice, map[*models.User]string{
+			bob:   models.BoardRoleEditor,
+			carol: models.BoardRoleViewer,
+		}); err != nil {
+			return err
+		}
+
+		labels := []models.Label{
+			{Name: "Bug", Color: "red"},
+			{Name: "Feature", Color: "green"},
+			{Name: "Design", Color: "purple"},
+			{Name: "Content", Color: "sky"},
+		}
+		labelIDs := map[string]int64{}
+		for i := range labels {
+			labels[i].BoardID = board.InternalID
+			labels[i].BoardPublicID = board.PublicID
+		}
+		if err := tx.Create(&labels).Error; err != nil {
+			return err
+		}
+		for _, label := range labels {
+			labelIDs[label.Name] = label.InternalID
+		}
+
+		lists, err := createLists(tx, board, []string{"Backlog", "In Progress", "Review", "Done"})
+		if err != nil {
+			return err
+		}
+
+		cardsByList := [][]demoCard{
+			{
+				{Title: "Write copy for the About page", Priority: models.CardPriorityLow, DueInDays: 14, Labels: []string{"Content"}},
+				{Title: "Add dark mode", Priority: models.CardPriorityMedium, Labels: []string{"Feature", "Design"}},
+				{Title: "Collect customer testimonials", Labels: []string{"Content"}, Assignees: []*models.User{bob}},
+			},
+			{
+				{Title: "New homepage hero section", Priority: models.CardPriorityHigh, DueInDays: 3, Labels: []string{"Design"}, Assignees: []*models.User{alice}},
+				{Title: "Contact form does not send email", Priority: models.CardPriorityUrgent, DueInDays: 1, Labels: []string{"Bug"}, Assignees: []*models.User{bob}},
+			},
+			{
+				{Title: "Navigation redesign", Priority: models.CardPriorityMedium, DueInDays: -1, Labels: []string{"Design", "Feature"}, Assignees: []*models.User{alice, bob}},
+			},
+			{
+				{Title: "Choose the new color palette", Labels: []string{"Design"}, Assignees: []*models.User{alice}},
+			},
+		}
+
+		now := time.Now()
+		for i, specs := range cardsByList {
+			cards := make([]models.Card, len(specs))
+			for j, spec := range specs {
+				cards[j] = models.Card{Title: spec.Title, Priority: spec.Priority}
+				if spec.DueInDays != 0 {
+					due := now.AddDate(0, 0, spec.DueInDays)
+					cards[j].DueDate = &due
+				}
+				if i == len(cardsByList)-1 {
+					cards[j].DueComplete = true
+					cards[j].CompletedAt = &now
+				}
+			}
+			if err := createCards(tx, &lists[i], cards); err != nil {
+				return err
+			}
+
+			var cardLabels []models.CardLabel
+			var assignees []models.CardAssignee
+			for j, spec := range specs {
+				for _, name := range spec.Labels {
+					cardLabels = append(cardLabels, models.CardLabel{CardID: cards[j].InternalId, LabelID: labelIDs[name]})
+				}
+				for _, user := range spec.Assignees {
+					assignees = append(assignees, models.CardAssignee{CardID: cards[j].InternalId, UserID: user.InternalID})
+				}
+			}
+			if len(cardLabels) > 0 {
+				if err := tx.Create(&cardLabels).Error; err != nil {
+					return err
+				}
+			}
+			if len(assignees) > 0 {
+				if err := tx.Create(&assignees).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,175 @@
+package seed
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// Ukuran dataset load testing per user sintetis.
+const (
+	LoadPassword        = "load1234" // Password semua user sintetis
+	DefaultLoadScale    = 100        // Jumlah user sintetis jika --scale tidak diisi
+	loadListsPerBoard   = 5
+	loadCardsPerList    = 20
+	loadMembersPerBoard = 3 // Selain owner, board juga berisi beberapa user sintetis berikutnya
+)
+
+var loadLabelColors = []string{"green", "yellow", "orange", "red", "purple", "blue"}
+
+// loadSeeder membuat dataset sintetis besar: LoadScale user, masing-masing memiliki satu board
+// berisi loadListsPerBoard list x loadCardsPerList kartu, label, assignee, dan member lain.
+//
+// Data dibuat deterministik (random dengan seed tetap), dan user yang board-nya sudah ada
+// dilewati. Menjalankan ulang dengan --scale lebih besar hanya menambah user baru.
+var loadSeeder = Seeder{
+	Name:               "load",
+	Description:        "synthetic load-testing dataset (--scale users, one board of 100 cards each)",
+	DefaultCredentials: true,
+	Run:                seedLoad,
+}
+
+func seedLoad(ctx context.Context, db *gorm.DB, opts Options) error {
+	scale := opts.LoadScale
+	if scale <= 0 {
+		scale = DefaultLoadScale
+	}
+
+	// bcrypt sengaja lambat, jadi cukup di-hash sekali untuk semua user sintetis.
+	password, err := utils.HashPassword(LoadPassword)
+	if err != nil {
+		return err
+	}
+
+	users := make([]*models.User, scale)
+	for i := range users {
+		users[i] = &models.User{
+			Name:     fmt.Sprintf("Load User %05d", i),
+			Email:    fmt.Sprintf("load-%05d@load.test", i),
+			Password: password,
+			Role:     "user",
+		}
+		if err := findOrCreateUser(db, users[i]); err != nil {
+			return err
+		}
+	}
+
+	created := 0
+	for i, owner := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		title := fmt.Sprintf("Load Board %05d", i)
+		var existing int64
+		if err := db.Model(&models.Board{}).
+			Where("owner_internal_id = ? AND title = ?", owner.InternalID, title).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			continue
+		}
+
+		members := map[*models.User]string{}
+		for m := 1; m <= loadMembersPerBoard && m < scale; m++ {
+			members[users[(i+m)%scale]] = models.BoardRoleEditor
+		}
+
+		// Seed per board agar hasilnya sama walaupun sebagian board sudah ada.
+		rng := rand.New(rand.NewPCG(uint64(i), 0))
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return seedLoadBoard(tx, rng, title, owner, members)
+		}); err != nil {
+			return err
+		}
+
+		created++
+		if created%100 == 0 {
+			log.Printf("Seeded %d load boards", created)
+		}
+	}
+	return nil
+}
+
+// seedLoadBoard membuat satu board sintetis beserta isinya.
+func seedLoadBoard(tx *gorm.DB, rng *rand.Rand, title string, owner *models.User, members map[*models.User]string) error {
+	board := &models.Board{Title: title, Description: "Synthetic board for load testing."}
+	if err := createBoard(tx, board, owner, members); err != nil {
+		return err
+	}
+
+	labels := make([]models.Label, len(loadLabelColors))
+	for i, color := range loadLabelColors {
+		labels[i] = models.Label{BoardID: board.InternalID, BoardPublicID: board.PublicID, Name: fmt.Sprintf("Label %d", i+1), Color: color}
+	}
+	if err := tx.Create(&labels).Error; err != nil {
+		return err
+	}
+
+	titles := make([]string, loadListsPerBoard)
+	for i := range titles {
+		titles[i] = fmt.Sprintf("List %d", i+1)
+	}
+	lists, err := createLists(tx, board, titles)
+	if err != nil {
+		return err
+	}
+
+	assignable := []*models.User{owner}
+	for user := range members {
+		assignable = append(assignable, user)
+	}
+	// Urutan map acak, jadi diurutkan agar pilihan assignee tetap deterministik.
+	slices.SortFunc(assignable, func(a, b *models.User) int { return cmp.Compare(a.InternalID, b.InternalID) })
+	priorities := []string{"", models.CardPriorityLow, models.CardPriorityMedium, models.CardPriorityHigh, models.CardPriorityUrgent}
+	now := time.Now()
+
+	var cardLabels []models.CardLabel
+	var assignees []models.CardAssignee
+	for i := range lists {
+		cards := make([]models.Card, loadCardsPerList)
+		for j := range cards {
+			cards[j] = models.Card{
+				Title:       fmt.Sprintf("Card %d-%d", i+1, j+1),
+				Description: "Synthetic card for load testing.",
+				Priority:    priorities[rng.IntN(len(priorities))],
+			}
+			if rng.IntN(3) == 0 {
+				due := now.AddDate(0, 0, rng.IntN(60)-30)
+				cards[j].DueDate = &due
+			}
+		}
+		if err := createCards(tx, &lists[i], cards); err != nil {
+			return err
+		}
+
+		for _, card := range cards {
+			for _, k := range rng.Perm(len(labels))[:rng.IntN(3)] {
+				cardLabels = append(cardLabels, models.CardLabel{CardID: card.InternalId, LabelID: labels[k].InternalID})
+			}
+			if rng.IntN(2) == 0 {
+				user := assignable[rng.IntN(len(assignable))]
+				assignees = append(assignees, models.CardAssignee{CardID: card.InternalId, UserID: user.InternalID})
+			}
+		}
+	}
+
+	if len(cardLabels) > 0 {
+		if err := tx.CreateInBatches(&cardLabels, 500).Error; err != nil {
+			return err
+		}
+	}
+	if len(assignees) > 0 {
+		return tx.CreateInBatches(&assignees, 500).Error
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package seed
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSelectSeeders(t *testing.T) {
+	tests := []struct {
+		name       string
+		names      []string
+		production bool
+		want       []string
+		wantErr    error
+	}{
+		{"default selection", nil, false, []string{"admin", "demo"}, nil},
+		{"default selection in production skips known passwords", nil, true, []string{"admin"}, nil},
+		{"explicit names follow All order", []string{"load", "admin"}, false, []string{"admin", "load"}, nil},
+		{"names are trimmed and case insensitive", []string{" Demo "}, false, []string{"demo"}, nil},
+		{"explicit admin in production", []string{"admin"}, true, []string{"admin"}, nil},
+		{"explicit demo in production", []string{"demo"}, true, nil, ErrDefaultCredentials},
+		{"explicit load in production", []string{"admin", "load"}, true, nil, ErrDefaultCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seeders, err := selectSeeders(tt.names, tt.production)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("selectSeeders(%q, %v) error = %v, want %v", tt.names, tt.production, err, tt.wantErr)
+			}
+
+			var got []string
+			for _, seeder := range seeders {
+				got = append(got, seeder.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectSeeders(%q, %v) = %q, want %q", tt.names, tt.production, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectSeedersUnknown(t *testing.T) {
+	if _, err := selectSeeders([]string{"admin", "nope"}, false); err == nil {
+		t.Error("selectSeeders accepted an unknown seeder name")
+	}
+}
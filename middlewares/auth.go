@@ -0,0 +1,99 @@
+// Package middlewares berisi middleware Fiber yang dijalankan sebelum handler.
+package middlewares
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+// principalKey adalah key c.Locals tempat Principal disimpan.
+// Dibuat unexported agar handler wajib memakai CurrentUser() untuk membacanya.
+const principalKey = "principal"
+
+// Error autentikasi yang dikirim middleware Protected (lihat katalog kode di utils/errors.go).
+var (
+	errNoToken      = errors.New("no token provided")
+	errTokenRevoked = errors.New("token has been revoked")
+
+	errTokenRequired = utils.NewAppError(fiber.StatusUnauthorized, utils.CodeTokenRequired, "Token required")
+	errTokenInvalid  = utils.NewAppError(fiber.StatusUnauthorized, utils.CodeTokenInvalid, "Invalid token")
+	errTokenExpired  = utils.NewAppError(fiber.StatusUnauthorized, utils.CodeTokenExpired, "Token expired")
+)
+
+// RevocationChecker adalah kontrak untuk mengecek apakah token sudah dicabut (logout).
+// Diimplementasikan oleh services.TokenRevocationService.
+type RevocationChecker interface {
+	IsRevoked(principal *utils.Principal) (bool, error)
+}
+
+// Protected adalah middleware yang mewajibkan header
+// "Authorization: Bearer <token>" berisi JWT yang valid dan belum dicabut.
+//
+// Jika valid, identitas user disimpan di c.Locals dan bisa diambil dengan CurrentUser(c).
+// Jika tidak, request langsung dihentikan dengan response 401.
+//
+// Contoh penggunaan:
+//
+//	boards := api.Group("/boards", middlewares.Protected(revocationService))
+func Protected(revocations RevocationChecker) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		tokenString, found := strings.CutPrefix(header, "Bearer ")
+		if !found || strings.TrimSpace(tokenString) == "" {
+			return utils.SendError(c, errTokenRequired.Wrap(errNoToken))
+		}
+
+		principal, err := utils.ParseToken(strings.TrimSpace(tokenString))
+		if err != nil {
+			// Token kedaluwarsa diberi kode sendiri agar client tahu harus memanggil /auth/refresh.
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				return utils.SendError(c, errTokenExpired.Wrap(err))
+			}
+			return utils.SendError(c, errTokenInvalid.Wrap(err))
+		}
+
+		revoked, err := revocations.IsRevoked(principal)
+		if err != nil {
+			return utils.InternalServerError(c, "Failed to verify token", err.Error())
+		}
+		if revoked {
+			return utils.SendError(c, errTokenInvalid.Wrap(errTokenRevoked))
+		}
+
+		c.Locals(principalKey, principal)
+		return c.Next()
+	}
+}
+
+// RequireRole adalah middleware yang hanya meloloskan user dengan role global tertentu.
+// Harus dipasang SETELAH Protected.
+//
+// Contoh penggunaan:
+//
+//	router.Post("/:id/revoke-tokens", middlewares.RequireRole("admin"), ctrl.RevokeTokens)
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal := CurrentUser(c)
+		if principal == nil {
+			return utils.SendError(c, errTokenRequired.Wrap(errNoToken))
+		}
+
+		for _, role := range roles {
+			if principal.Role == role {
+				return c.Next()
+			}
+		}
+		return utils.Forbidden(c, "Access denied", "insufficient role")
+	}
+}
+
+// CurrentUser mengambil Principal yang disimpan oleh middleware Protected.
+// Mengembalikan nil jika route tidak dilindungi middleware tersebut.
+func CurrentUser(c *fiber.Ctx) *utils.Principal {
+	principal, _ := c.Locals(principalKey).(*utils.Principal)
+	return principal
+}
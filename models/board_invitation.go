@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Status undangan board.
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// BoardInvitation adalah undangan untuk bergabung ke sebuah board lewat email.
+//
+// Pengundang cukup tahu email orang yang diundang (tidak perlu ID internal user).
+// Undangan membawa token rahasia yang berlaku sampai ExpiresAt. Sama seperti refresh token,
+// yang disimpan hanya hash-nya, token asli hanya ditampilkan sekali saat undangan dibuat.
+type BoardInvitation struct {
+	// InternalID: Primary Key.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+
+	// PublicID: ID unik API.
+	PublicID uuid.UUID `json:"public_id" db:"public_id"`
+
+	// BoardID: Board tujuan undangan (Foreign Key).
+	BoardID int64 `json:"-" db:"board_internal_id" gorm:"column:board_internal_id;not null;index"`
+
+	// BoardPublicID: ID Public board, agar response tidak perlu JOIN ke tabel boards.
+	BoardPublicID uuid.UUID `json:"board_public_id" db:"board_public_id"`
+
+	// Email: Email orang yang diundang (selalu huruf kecil).
+	Email string `json:"email" db:"email" gorm:"not null;index"`
+
+	// Role: Role board yang akan didapat user saat undangan diterima.
+	Role string `json:"role" db:"role" gorm:"not null;default:editor"`
+
+	// InvitedByID: User yang mengirim undangan.
+	InvitedByID int64 `json:"-" db:"invited_by_internal_id" gorm:"column:invited_by_internal_id;not null"`
+
+	// TokenHash: SHA-256 dari token undangan.
+	TokenHash string `json:"-" db:"token_hash" gorm:"not null;uniqueIndex"`
+
+	// Status: pending, accepted, declined, atau revoked.
+	Status string `json:"status" db:"status" gorm:"not null;default:pending"`
+
+	// ExpiresAt: Batas waktu undangan bisa diterima.
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at" gorm:"not null"`
+
+	// RespondedAt: Kapan undangan diterima/ditolak/dicabut.
+	RespondedAt *time.Time `json:"responded_at,omitempty" db:"responded_at"`
+
+	// CreatedAt: Waktu undangan dibuat.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// BeforeCreate mengisi PublicID secara otomatis sebelum undangan disimpan.
+func (i *BoardInvitation) BeforeCreate(tx *gorm.DB) error {
+	if i.PublicID == uuid.Nil {
+		i.PublicID = uuid.New()
+	}
+	return nil
+}
@@ -1,6 +1,29 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role member di dalam sebuah board (berbeda dengan models.User.Role yang berlaku global).
+// Urutan dari hak akses paling besar ke paling kecil.
+const (
+	BoardRoleOwner     = "owner"     // Pembuat board, hak penuh termasuk menghapus board
+	BoardRoleAdmin     = "admin"     // Mengelola board, member, list, dan kartu
+	BoardRoleEditor    = "editor"    // Membuat dan mengubah list, kartu, dan komentar
+	BoardRoleCommenter = "commenter" // Hanya membaca dan berkomentar
+	BoardRoleViewer    = "viewer"    // Hanya membaca
+)
+
+// IsValidBoardRole mengecek apakah role termasuk role board yang dikenal.
+func IsValidBoardRole(role string) bool {
+	switch role {
+	case BoardRoleOwner, BoardRoleAdmin, BoardRoleEditor, BoardRoleCommenter, BoardRoleViewer:
+		return true
+	}
+	return false
+}
 
 // BoardMember merepresentasikan hubungan "Many-to-Many" antara Board dan User.
 // Satu Board bisa punya banyak Member (User), dan satu User bisa join ke banyak Board.
@@ -9,12 +32,27 @@ type BoardMember struct {
 	// BoardID: ID dari Board.
 	// Tag `gorm:"primaryKey"` (digabung dengan UserID) membuat "Composite Primary Key".
 	// Artinya, kombinasi BoardID + UserID harus unik. Tidak bisa ada duplikat (User yg sama join Board yg sama 2x).
-	BoardID int64 `json:"board_internal_id" db:"board_internal_id" gorm:"column:board_internal_id;primaryKey"`
+	BoardID int64 `json:"-" db:"board_internal_id" gorm:"column:board_internal_id;primaryKey"`
 
 	// UserID: ID dari User yang menjadi member.
 	// Ini juga bagian dari Primary Key.
-	UserID int64 `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id;primaryKey"`
+	UserID int64 `json:"-" db:"user_internal_id" gorm:"column:user_internal_id;primaryKey"`
+
+	// Role: Peran user di board ini (owner, admin, editor, commenter, viewer).
+	Role string `json:"role" db:"role" gorm:"not null;default:editor"`
 
 	// JoinedAt: Kapan user tersebut join ke board ini.
 	JoinedAt time.Time `json:"joined_at" db:"joined_at"`
 }
+
+// BoardMemberDetail adalah hasil JOIN board_members dengan users.
+// Dipakai untuk response daftar member, sehingga client mendapat nama & email
+// tanpa melihat ID internal.
+type BoardMemberDetail struct {
+	UserInternalID int64     `json:"-"`
+	UserPublicID   uuid.UUID `json:"user_public_id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role"`
+	JoinedAt       time.Time `json:"joined_at"`
+}
@@ -4,14 +4,34 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-// Card merepresentasikan tugas atau item dalam sebuah List (seperti kartu di Trello).
+// Prioritas kartu. String kosong berarti kartu tidak diberi prioritas.
+const (
+	CardPriorityLow    = "low"
+	CardPriorityMedium = "medium"
+	CardPriorityHigh   = "high"
+	CardPriorityUrgent = "urgent"
+)
+
+// Status due date kartu (Card.DueStatus), dihitung saat kartu dibaca.
+const (
+	DueStatusComplete = "complete" // Kartu sudah ditandai selesai (DueComplete)
+	DueStatusOverdue  = "overdue"  // Due date sudah lewat dan kartu belum selesai
+	DueStatusDueSoon  = "due_soon" // Due date kurang dari DueSoonWindow lagi
+	DueStatusUpcoming = "upcoming" // Due date masih lama
+)
+
+// DueSoonWindow adalah rentang waktu sebelum due date di mana kartu dianggap "due_soon".
+const DueSoonWindow = 24 * time.Hour
+
 // Card merepresentasikan tugas atau item dalam sebuah List (seperti kartu di Trello).
 type Card struct {
 	// InternalId: Primary Key database.
 	// Tag `gorm:"primaryKey"` menandakan ini kolom utama.
-	InternalId int64 `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	// Disembunyikan dari API (`json:"-"`), kartu diakses lewat PublicId.
+	InternalId int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
 
 	// PublicId: ID unik untuk API.
 	// Tag `json:"public_id"` mengubah nama field jadi snake_case di JSON.
@@ -19,7 +39,11 @@ type Card struct {
 
 	// ListID: Menandakan kartu ini ada di List mana (Foreign Key).
 	// Tag `gorm:"column:list_id"` memaksa nama kolom di DB jadi 'list_id'.
-	ListID int64 `json:"list_id" db:"list_id" gorm:"column:list_id"`
+	ListID int64 `json:"-" db:"list_id" gorm:"column:list_id"`
+
+	// ListPublicID: ID Public dari List tempat kartu berada.
+	// Disimpan agar response kartu langsung berisi UUID list tanpa JOIN ke tabel lists.
+	ListPublicID uuid.UUID `json:"list_public_id" db:"list_public_id" gorm:"column:list_public_id"`
 
 	// Title: Judul kartu.
 	Title string `json:"title" db:"title"`
@@ -31,10 +55,85 @@ type Card struct {
 	// Tag `json:"due_date,omitempty"` berarti field ini hilang dari JSON jika nilainya kosong (nil).
 	DueDate *time.Time `json:"due_date,omitempty" db:"due_date"`
 
-	// Position: Menentukan urutan kartu dalam List (misal: 1, 2, 3).
-	// Tag `json:"position"` untuk nama field di API.
+	// StartDate: Tanggal mulai pengerjaan (Opsional). Jika DueDate juga diisi, StartDate tidak boleh setelah DueDate.
+	StartDate *time.Time `json:"start_date,omitempty" db:"start_date"`
+
+	// DueComplete: Penanda kartu sudah selesai dikerjakan ("due complete" di Trello).
+	// Kartu yang selesai tidak lagi dianggap overdue dan tidak muncul di workload.
+	DueComplete bool `json:"due_complete" db:"due_complete"`
+
+	// CompletedAt: Kapan kartu ditandai selesai. Kosong (nil) jika DueComplete false.
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+
+	// DueStatus: Status due date (lihat konstanta DueStatus*). Kosong jika kartu belum selesai
+	// dan tidak punya due date. Tidak disimpan di database (`gorm:"-"`), dihitung oleh FillDueStatus.
+	DueStatus string `json:"due_status,omitempty" gorm:"-"`
+
+	// Priority: Prioritas kartu (lihat konstanta CardPriority*), boleh kosong.
+	Priority string `json:"priority,omitempty" db:"priority"`
+
+	// Estimate: Perkiraan ukuran pekerjaan dalam satuan yang disepakati tim (misal story point).
+	Estimate *int `json:"estimate,omitempty" db:"estimate"`
+
+	// CoverAttachmentID: Attachment gambar yang dijadikan cover kartu. Harus attachment milik kartu ini.
+	// Jika attachment-nya dihapus, foreign key ON DELETE SET NULL mengosongkan cover secara otomatis.
+	CoverAttachmentID *int64 `json:"-" db:"cover_attachment_internal_id" gorm:"column:cover_attachment_internal_id"`
+
+	// CoverAttachmentPublicID: ID Public attachment cover. Client menampilkan cover lewat
+	// GET /api/attachments/:id/thumbnail.
+	CoverAttachmentPublicID *uuid.UUID `json:"cover_attachment_id,omitempty" db:"cover_attachment_public_id" gorm:"column:cover_attachment_public_id"`
+
+	// Position: Index kartu di dalam List (0, 1, 2, ...).
+	//
+	// Dengan ORDERING_STRATEGY=array, sumber kebenaran urutan kartu adalah CardPosition.CardOrder.
+	// Kolom ini hanyalah salinan index dari CardOrder yang ikut diperbarui di transaksi yang sama,
+	// sehingga client bisa langsung membaca posisi kartu tanpa mengambil CardOrder.
+	// Dengan ORDERING_STRATEGY=rank, nilainya dihitung saat kartu dibaca.
 	Position int `json:"position" db:"position"`
 
+	// Rank: Kunci urutan leksikografis (fractional index), hanya dipakai jika
+	// ORDERING_STRATEGY=rank. Kartu diurutkan dengan "ORDER BY rank" dan memindahkan
+	// kartu cukup mengubah rank kartu itu sendiri. Lihat utils.RankBetween.
+	Rank string `json:"rank,omitempty" db:"rank" gorm:"column:rank"`
+
+	// ChecklistTotal & ChecklistDone: Progress semua checklist di kartu (jumlah item & item
+	// yang sudah dicentang). Dihitung ulang oleh repository setiap kali checklist/item berubah.
+	ChecklistTotal int `json:"checklist_total" db:"checklist_total"`
+	ChecklistDone  int `json:"checklist_done" db:"checklist_done"`
+
 	// CreatedAt: Waktu pembuatan.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// UpdatedAt: Waktu terakhir kartu diubah.
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// BeforeCreate mengisi PublicId secara otomatis sebelum kartu disimpan.
+func (c *Card) BeforeCreate(tx *gorm.DB) error {
+	if c.PublicId == uuid.Nil {
+		c.PublicId = uuid.New()
+	}
+	return nil
+}
+
+// AfterFind mengisi DueStatus setiap kali kartu dibaca dari database.
+func (c *Card) AfterFind(tx *gorm.DB) error {
+	c.FillDueStatus(time.Now())
+	return nil
+}
+
+// FillDueStatus menghitung DueStatus relatif terhadap waktu now.
+func (c *Card) FillDueStatus(now time.Time) {
+	switch {
+	case c.DueComplete:
+		c.DueStatus = DueStatusComplete
+	case c.DueDate == nil:
+		c.DueStatus = ""
+	case c.DueDate.Before(now):
+		c.DueStatus = DueStatusOverdue
+	case c.DueDate.Before(now.Add(DueSoonWindow)):
+		c.DueStatus = DueStatusDueSoon
+	default:
+		c.DueStatus = DueStatusUpcoming
+	}
 }
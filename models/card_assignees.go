@@ -1,18 +1,50 @@
 package models
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // CardAssignee adalah "Pivot Table" untuk menentukan siapa saja yang mengerjakan kartu ini.
 // Relasi: Many-to-Many (Satu kartu bisa dikerjakan banyak user, satu user bisa kerjakan banyak kartu).
-// CardAssignee adalah "Pivot Table" untuk menentukan siapa saja yang mengerjakan kartu ini.
-// Relasi: Many-to-Many (Satu kartu bisa dikerjakan banyak user, satu user bisa kerjakan banyak kartu).
+// Hanya member board yang boleh ditugaskan ke kartu di board tersebut.
 type CardAssignee struct {
 	// CardID: ID Kartu.
 	// Tag `gorm:"column:card_internal_id"`:
 	// Memaksa GORM menamai kolom di database sebagai `card_internal_id`.
 	// Jika tidak pakai ini, GORM mungkin akan menamainya `card_id` (sesuai nama field).
-	CardID int64 `json:"card_internal_id" db:"card_internal_id" gorm:"column:card_internal_id"`
+	CardID int64 `json:"-" db:"card_internal_id" gorm:"column:card_internal_id;primaryKey"`
 
 	// UserID: ID User yang ditugaskan (Assignee).
 	// Tag `gorm:"column:user_internal_id"`:
 	// Memaksa GORM menamai kolom di database sebagai `user_internal_id`.
-	UserID int64 `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id"`
+	UserID int64 `json:"-" db:"user_internal_id" gorm:"column:user_internal_id;primaryKey"`
+
+	// AssignedAt: Waktu user ditugaskan ke kartu.
+	AssignedAt time.Time `json:"assigned_at" db:"assigned_at" gorm:"autoCreateTime"`
+}
+
+// CardAssigneeDetail adalah hasil JOIN card_assignees dengan users,
+// dipakai untuk response daftar assignee kartu.
+type CardAssigneeDetail struct {
+	UserPublicID uuid.UUID `json:"user_public_id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	AssignedAt   time.Time `json:"assigned_at"`
+}
+
+// AssignedCard adalah satu kartu di workload user: data kartu beserta list dan board-nya
+// (hasil JOIN card_assignees, cards, lists, dan boards).
+type AssignedCard struct {
+	CardPublicID  uuid.UUID  `json:"card_id"`
+	Title         string     `json:"title"`
+	DueDate       *time.Time `json:"due_date,omitempty"`
+	StartDate     *time.Time `json:"start_date,omitempty"`
+	Priority      string     `json:"priority,omitempty"`
+	ListPublicID  uuid.UUID  `json:"list_id"`
+	ListTitle     string     `json:"list_title"`
+	BoardPublicID uuid.UUID  `json:"-"`
+	BoardTitle    string     `json:"-"`
+	AssignedAt    time.Time  `json:"assigned_at"`
 }
@@ -1,30 +1,92 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Status pembuatan thumbnail attachment. Attachment yang bukan gambar berstatus kosong.
+const (
+	ThumbnailStatusPending = "pending" // Menunggu diproses thumbnail worker
+	ThumbnailStatusReady   = "ready"   // Thumbnail tersedia di ThumbnailURL
+	ThumbnailStatusFailed  = "failed"  // Gambar tidak bisa dibaca (rusak atau terlalu besar)
 )
 
 // CardAttachment merepresentasikan file lampiran yang di-upload ke sebuah kartu.
+// Isi file disimpan di storage (folder lokal atau S3), database hanya menyimpan metadata-nya.
 type CardAttachment struct {
-	// InternalID: Primary Key.
-	InternalID int64 `json:"internal_id" db:"internal_id" gorm:"primarykey;autoIncrement"`
+	// InternalID: Primary Key. Disembunyikan dari API, attachment diakses lewat PublicID.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primarykey;autoIncrement"`
 
-	// PublicID: ID unik API.
+	// PublicID: ID unik API. Dipakai juga untuk URL download: GET /api/attachments/:id/download.
 	PublicID uuid.UUID `json:"public_id" db:"public_id"`
 
-	// File: Menyimpan path atau URL file yang di-upload.
-	// Contoh: "/uploads/images/foto.jpg" atau "https://s3.aws.com/bucket/file.pdf".
+	// File: Key object di storage, misal "cards/<card-uuid>/<attachment-uuid>".
 	// Database tidak menyimpan file fisiknya (blob), tapi hanya lokasinya (string).
-	File string `json:"file" db:"file"`
+	// Disembunyikan dari API: client selalu download lewat endpoint yang mengecek hak akses.
+	File string `json:"-" db:"file"`
+
+	// FileName: Nama file asli dari client, dipakai di header Content-Disposition saat download.
+	FileName string `json:"file_name" db:"file_name"`
+
+	// ContentType: MIME type hasil deteksi isi file (bukan dari header client), misal "image/png".
+	ContentType string `json:"content_type" db:"content_type"`
+
+	// Size: Ukuran file dalam byte.
+	Size int64 `json:"size" db:"size"`
 
 	// UserID: Siapa yang upload file ini.
-	UserID int64 `json:"user_internal_id" db:"user_internal_id"`
+	UserID int64 `json:"-" db:"user_internal_id" gorm:"column:user_internal_id"`
+
+	// UserPublicID: ID Public user yang upload.
+	UserPublicID uuid.UUID `json:"uploaded_by" db:"user_public_id" gorm:"column:user_public_id"`
 
 	// CardID: File ini milik kartu yang mana.
-	CardID int64 `json:"card_internal_id" db:"card_internal_id"`
+	CardID int64 `json:"-" db:"card_internal_id" gorm:"column:card_internal_id"`
+
+	// CardPublicID: ID Public kartu pemilik file.
+	CardPublicID uuid.UUID `json:"card_id" db:"card_public_id" gorm:"column:card_public_id"`
+
+	// Width & Height: Dimensi gambar asli dalam pixel. Terisi setelah thumbnail worker
+	// memproses gambar; nil untuk file yang bukan gambar.
+	Width  *int `json:"width,omitempty" db:"width"`
+	Height *int `json:"height,omitempty" db:"height"`
+
+	// ThumbnailKey: Key object thumbnail di storage, disimpan di samping file aslinya.
+	ThumbnailKey string `json:"-" db:"thumbnail_key"`
+
+	// ThumbnailStatus: Lihat konstanta ThumbnailStatus*.
+	ThumbnailStatus string `json:"thumbnail_status,omitempty" db:"thumbnail_status"`
+
+	// ThumbnailURL: URL thumbnail (selalu image/jpeg), hanya terisi jika statusnya "ready".
+	// Tidak disimpan di database (`gorm:"-"`), dihitung dari PublicID oleh FillThumbnailURL.
+	ThumbnailURL string `json:"thumbnail_url,omitempty" gorm:"-"`
 
 	// CreatedAt: Kapan file di-upload.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
+
+// BeforeCreate mengisi PublicID secara otomatis sebelum attachment disimpan.
+func (a *CardAttachment) BeforeCreate(tx *gorm.DB) error {
+	if a.PublicID == uuid.Nil {
+		a.PublicID = uuid.New()
+	}
+	return nil
+}
+
+// AfterFind mengisi ThumbnailURL setiap kali attachment dibaca dari database.
+func (a *CardAttachment) AfterFind(tx *gorm.DB) error {
+	a.FillThumbnailURL()
+	return nil
+}
+
+// FillThumbnailURL mengisi ThumbnailURL sesuai ThumbnailStatus.
+func (a *CardAttachment) FillThumbnailURL() {
+	a.ThumbnailURL = ""
+	if a.ThumbnailStatus == ThumbnailStatusReady {
+		a.ThumbnailURL = fmt.Sprintf("/api/attachments/%s/thumbnail", a.PublicID)
+	}
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"github.com/google/uuid"
 	"github.com/rakafajars/go-manajemen-project/models/types"
+	"gorm.io/gorm"
 )
 
 // CardPosition menyimpan urutan Kartu (Card) di dalam sebuah List.
@@ -11,18 +12,29 @@ import (
 type CardPosition struct {
 	// InternalID: Primary Key.
 	// Tag `gorm:"primarykey"` (huruf kecil semua juga boleh, tapi standar GORM biasanya camelCase `primaryKey`).
-	InternalID int64 `json:"internal_id" gorm:"primarykey;autoIncrement"`
+	InternalID int64 `json:"-" gorm:"primarykey;autoIncrement"`
 
 	// PublicID: ID unik API.
 	// Tag `not null` memastikan kolom ini wajib diisi di database.
 	PublicID uuid.UUID `json:"public_id" gorm:"type:uuid;not null"`
 
-	// ListID: Menandakan urutan kartu ini milik List yang mana.
+	// ListID: Menandakan urutan kartu ini milik List yang mana (satu baris per list).
 	// Tag `column:list_internal_id` memaksa nama kolom di DB jadi `list_internal_id`.
-	ListID int64 `json:"list_internal_id" gorm:"column:list_internal_id;not null"`
+	ListID int64 `json:"-" gorm:"column:list_internal_id;not null;uniqueIndex"`
 
 	// CardOrder: Array UUID yang menyimpan urutan kartu.
 	// Contoh: ["uuid-kartu-atas", "uuid-kartu-tengah", "uuid-kartu-bawah"]
 	// Tag `type:uuid[]` memastikan kolom di PostgreSQL bertipe Array.
 	CardOrder types.UUIDArray `json:"card_order" gorm:"type:uuid[]"`
 }
+
+// BeforeCreate mengisi PublicID dan memastikan CardOrder tidak NULL.
+func (p *CardPosition) BeforeCreate(tx *gorm.DB) error {
+	if p.PublicID == uuid.Nil {
+		p.PublicID = uuid.New()
+	}
+	if p.CardOrder == nil {
+		p.CardOrder = types.UUIDArray{}
+	}
+	return nil
+}
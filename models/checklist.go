@@ -0,0 +1,109 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Checklist adalah daftar sub-tugas di dalam sebuah kartu (Card).
+// Satu kartu boleh punya beberapa checklist, misal "Persiapan" dan "Review".
+type Checklist struct {
+	// InternalID: Primary Key. Disembunyikan dari API, checklist diakses lewat PublicID.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+
+	// PublicID: ID unik API.
+	PublicID uuid.UUID `json:"public_id" db:"public_id"`
+
+	// CardID: ID Internal kartu pemilik checklist (Foreign Key).
+	CardID int64 `json:"-" db:"card_internal_id" gorm:"column:card_internal_id"`
+
+	// CardPublicID: ID Public kartu pemilik checklist.
+	CardPublicID uuid.UUID `json:"card_id" db:"card_public_id" gorm:"column:card_public_id"`
+
+	// Title: Judul checklist.
+	Title string `json:"title" db:"title"`
+
+	// Rank: Kunci urutan checklist di kartu (fractional index, lihat utils.RankBetween).
+	// Disembunyikan dari API: client cukup memakai Position.
+	Rank string `json:"-" db:"rank" gorm:"column:rank"`
+
+	// Position: Index checklist di kartu (0, 1, 2, ...). Tidak disimpan di database,
+	// dihitung dari urutan Rank saat checklist dibaca.
+	Position int `json:"position" gorm:"-"`
+
+	// Items: Item-item di checklist, urut berdasarkan Rank. Diisi lewat Preload.
+	Items []ChecklistItem `json:"items" gorm:"foreignKey:ChecklistID;references:InternalID"`
+
+	// CreatedAt: Waktu checklist dibuat.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// UpdatedAt: Waktu terakhir checklist diubah.
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// BeforeCreate mengisi PublicID secara otomatis sebelum checklist disimpan.
+func (c *Checklist) BeforeCreate(tx *gorm.DB) error {
+	if c.PublicID == uuid.Nil {
+		c.PublicID = uuid.New()
+	}
+	return nil
+}
+
+// ChecklistItem adalah satu sub-tugas di dalam Checklist yang bisa dicentang.
+type ChecklistItem struct {
+	// InternalID: Primary Key. Disembunyikan dari API, item diakses lewat PublicID.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+
+	// PublicID: ID unik API.
+	PublicID uuid.UUID `json:"public_id" db:"public_id"`
+
+	// ChecklistID: ID Internal checklist pemilik item (Foreign Key).
+	ChecklistID int64 `json:"-" db:"checklist_internal_id" gorm:"column:checklist_internal_id"`
+
+	// ChecklistPublicID: ID Public checklist pemilik item.
+	ChecklistPublicID uuid.UUID `json:"checklist_id" db:"checklist_public_id" gorm:"column:checklist_public_id"`
+
+	// CardID: ID Internal kartu, disalin dari checklist agar progress kartu bisa dihitung tanpa JOIN.
+	CardID int64 `json:"-" db:"card_internal_id" gorm:"column:card_internal_id"`
+
+	// Title: Isi item.
+	Title string `json:"title" db:"title"`
+
+	// Rank: Kunci urutan item di checklist. Lihat Checklist.Rank.
+	Rank string `json:"-" db:"rank" gorm:"column:rank"`
+
+	// Position: Index item di checklist, dihitung dari urutan Rank saat item dibaca.
+	Position int `json:"position" gorm:"-"`
+
+	// Checked: Apakah item sudah dicentang (selesai).
+	Checked bool `json:"checked" db:"checked"`
+
+	// CheckedAt: Kapan item dicentang. Kosong (nil) jika belum dicentang.
+	CheckedAt *time.Time `json:"checked_at,omitempty" db:"checked_at"`
+
+	// AssigneeID: ID Internal user yang ditugaskan mengerjakan item (Opsional).
+	// Harus member board kartu ini.
+	AssigneeID *int64 `json:"-" db:"assignee_internal_id" gorm:"column:assignee_internal_id"`
+
+	// AssigneePublicID: ID Public user yang ditugaskan.
+	AssigneePublicID *uuid.UUID `json:"assignee_id,omitempty" db:"assignee_public_id" gorm:"column:assignee_public_id"`
+
+	// DueDate: Tenggat waktu item (Opsional).
+	DueDate *time.Time `json:"due_date,omitempty" db:"due_date"`
+
+	// CreatedAt: Waktu item dibuat.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// UpdatedAt: Waktu terakhir item diubah.
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// BeforeCreate mengisi PublicID secara otomatis sebelum item disimpan.
+func (i *ChecklistItem) BeforeCreate(tx *gorm.DB) error {
+	if i.PublicID == uuid.Nil {
+		i.PublicID = uuid.New()
+	}
+	return nil
+}
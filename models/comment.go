@@ -4,12 +4,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Comment merepresentasikan komentar user pada sebuah kartu (Card).
+// Komentar bisa berupa balasan (reply) dari komentar lain lewat ParentID.
 type Comment struct {
-	// InternalID: Primary Key database.
-	InternalID int64 `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	// InternalID: Primary Key database. Disembunyikan dari API, komentar diakses lewat PublicID.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
 
 	// PublicID: ID unik API.
 	PublicID uuid.UUID `json:"public_id" db:"public_id"`
@@ -17,23 +19,63 @@ type Comment struct {
 	// CardID: ID Internal Kartu (Foreign Key).
 	// Digunakan untuk relasi database yang efisien (JOIN).
 	// Tag `gorm:"column:card_internal_id"` memaksa nama kolom di DB.
-	CardID int64 `json:"card_internal_id" db:"card_internal_id" gorm:"column:card_internal_id"`
+	CardID int64 `json:"-" db:"card_internal_id" gorm:"column:card_internal_id"`
 
 	// CardPubID: ID Public Kartu.
 	// Disimpan agar saat API minta data comment, kita bisa langsung kasih ID Kartu-nya (UUID)
 	// tanpa harus JOIN ke tabel Card dulu.
-	CardPubID uuid.UUID `json:"card_id" db:"card_id"`
+	CardPubID uuid.UUID `json:"card_id" db:"card_public_id" gorm:"column:card_public_id"`
 
 	// UserID: ID Internal User yang membuat komentar (Foreign Key).
-	UserID int64 `json:"user_internal_id" db:"user_internal_id" gorm:"column:user_internal_id"`
+	UserID int64 `json:"-" db:"user_internal_id" gorm:"column:user_internal_id"`
 
 	// UserPubID: ID Public User.
 	// Sama alasannya, biar frontend langsung dapat UUID user tanpa join tabel User.
-	UserPubID uuid.UUID `json:"user_id" db:"user_id"`
+	UserPubID uuid.UUID `json:"user_id" db:"user_public_id" gorm:"column:user_public_id"`
+
+	// ParentID: ID Internal komentar yang dibalas. Nil berarti komentar utama (bukan balasan).
+	ParentID *int64 `json:"-" db:"parent_internal_id" gorm:"column:parent_internal_id"`
+
+	// ParentPubID: ID Public komentar yang dibalas, agar frontend bisa menyusun thread.
+	ParentPubID *uuid.UUID `json:"parent_id,omitempty" db:"parent_public_id" gorm:"column:parent_public_id"`
 
 	// Message: Isi komentar.
 	Message string `json:"message" db:"message"`
 
+	// Mentions: User yang di-mention (@nama) di Message. Diisi lewat Preload.
+	Mentions []CommentMention `json:"mentions" gorm:"foreignKey:CommentID;references:InternalID"`
+
+	// EditedAt: Waktu terakhir isi komentar diubah. Nil berarti belum pernah diedit.
+	// Isi sebelum diedit tersimpan di CommentRevision.
+	EditedAt *time.Time `json:"edited_at,omitempty" db:"edited_at"`
+
 	// CreatedAt: Waktu komentar dibuat.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// UpdatedAt: Waktu terakhir baris ini diubah.
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// DeletedAt: Soft delete. Komentar yang dihapus tidak muncul lagi di API,
+	// tetapi barisnya tetap ada agar balasan dan riwayatnya tidak ikut hilang.
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Deleted: true jika komentar ini sudah dihapus tetapi masih punya balasan. Komentar seperti
+	// ini tetap dikirim sebagai "tombstone" (tanpa isi) agar client bisa menyusun thread-nya.
+	Deleted bool `json:"deleted,omitempty" gorm:"-"`
+}
+
+// Tombstone mengosongkan isi komentar yang sudah dihapus dan menandainya Deleted.
+func (c *Comment) Tombstone() {
+	c.Message = ""
+	c.Mentions = []CommentMention{}
+	c.EditedAt = nil
+	c.Deleted = true
+}
+
+// BeforeCreate mengisi PublicID secara otomatis sebelum komentar disimpan.
+func (c *Comment) BeforeCreate(tx *gorm.DB) error {
+	if c.PublicID == uuid.Nil {
+		c.PublicID = uuid.New()
+	}
+	return nil
 }
@@ -0,0 +1,19 @@
+package models
+
+import "github.com/google/uuid"
+
+// CommentMention mencatat user yang di-mention (@nama) di sebuah komentar.
+// Primary key-nya gabungan (CommentID, UserID): satu user hanya dicatat sekali per komentar.
+type CommentMention struct {
+	// CommentID: ID Internal komentar (Foreign Key).
+	CommentID int64 `json:"-" db:"comment_internal_id" gorm:"column:comment_internal_id;primaryKey"`
+
+	// UserID: ID Internal user yang di-mention (Foreign Key).
+	UserID int64 `json:"-" db:"user_internal_id" gorm:"column:user_internal_id;primaryKey"`
+
+	// UserPublicID: ID Public user yang di-mention.
+	UserPublicID uuid.UUID `json:"user_id" db:"user_public_id" gorm:"column:user_public_id"`
+
+	// Handle: Teks setelah "@" yang dipakai di komentar, misal "rakafajar".
+	Handle string `json:"handle" db:"handle"`
+}
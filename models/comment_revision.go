@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentRevision menyimpan isi komentar SEBELUM diedit.
+// Setiap kali komentar diedit, satu baris baru ditambahkan, sehingga riwayat lengkap
+// perubahan komentar bisa ditampilkan (seperti "edited" di GitHub/Slack).
+type CommentRevision struct {
+	// InternalID: Primary Key database.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+
+	// CommentID: ID Internal komentar yang diedit (Foreign Key).
+	CommentID int64 `json:"-" db:"comment_internal_id" gorm:"column:comment_internal_id"`
+
+	// Message: Isi komentar sebelum diedit.
+	Message string `json:"message" db:"message"`
+
+	// EditedByID: ID Internal user yang melakukan edit.
+	EditedByID int64 `json:"-" db:"edited_by_internal_id" gorm:"column:edited_by_internal_id"`
+
+	// EditedByPublicID: ID Public user yang melakukan edit.
+	EditedByPublicID uuid.UUID `json:"edited_by" db:"edited_by_public_id" gorm:"column:edited_by_public_id"`
+
+	// CreatedAt: Waktu edit dilakukan (isi di atas berlaku sampai waktu ini).
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -1,23 +1,47 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
 
 // Label merepresentasikan tag warna yang bisa ditempel di kartu (Card).
 // Contoh: Label Merah ("Urgent"), Label Hijau ("Done").
+// Setiap label dimiliki satu Board dan hanya bisa ditempel di kartu board tersebut.
 type Label struct {
 	// InternalID: Primary Key.
 	// Tag `gorm:"primaryKey;autoIncrement"` -> Kunci utama, nambah sendiri.
-	InternalID int64 `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	// Disembunyikan dari API, label diakses lewat PublicID.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
 
 	// PublicID: ID unik API.
 	// Tag `json:"public_id"` -> Nama field di JSON.
 	PublicID uuid.UUID `json:"public_id" db:"public_id"`
 
-	// Name: Nama label (misal: "Urgent", "Bug", "Feature").
+	// BoardID: ID Internal Board pemilik label (Foreign Key).
+	BoardID int64 `json:"-" db:"board_internal_id" gorm:"column:board_internal_id"`
+
+	// BoardPublicID: ID Public Board pemilik label.
+	BoardPublicID uuid.UUID `json:"board_id" db:"board_public_id" gorm:"column:board_public_id"`
+
+	// Name: Nama label (misal: "Urgent", "Bug", "Feature"). Boleh kosong (label warna saja).
 	// GORM default: varchar(255).
 	Name string `json:"name" db:"name"`
 
-	// Color: Kode warna (Hex Code), misal: "#FF0000".
-	// GORM default: varchar(255).
+	// Color: Kode warna Hex (misal: "#ff0000") atau nama warna dari palette (misal: "red").
+	// Daftar palette ada di services.LabelPalette.
 	Color string `json:"color" db:"color"`
+
+	// CreatedAt: Waktu label dibuat.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// BeforeCreate mengisi PublicID secara otomatis sebelum label disimpan.
+func (l *Label) BeforeCreate(tx *gorm.DB) error {
+	if l.PublicID == uuid.Nil {
+		l.PublicID = uuid.New()
+	}
+	return nil
 }
@@ -4,12 +4,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // List merepresentasikan kolom daftar tugas (misal: "To Do", "In Progress", "Done").
 type List struct {
-	// InternalID: Primary Key database.
-	InternalID int64 `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	// InternalID: Primary Key database. Disembunyikan dari API, list diakses lewat PublicID.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
 
 	// PublicID: ID unik untuk API.
 	PublicID uuid.UUID `json:"public_id" db:"public_id"`
@@ -22,11 +23,26 @@ type List struct {
 	// Title: Judul List.
 	Title string `json:"title" db:"title"`
 
+	// Rank: Kunci urutan leksikografis (fractional index), hanya dipakai jika
+	// ORDERING_STRATEGY=rank. Dengan strategi "array", urutan ada di ListPosition.ListOrder.
+	Rank string `json:"rank,omitempty" db:"rank" gorm:"column:rank"`
+
 	// CreatedAt: Waktu pembuatan.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 
+	// UpdatedAt: Waktu terakhir list diubah (misal di-rename).
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
 	// BoardInternalID: Foreign Key asli ke tabel Board (menggunakan InternalID).
 	// Ini yang digunakan database untuk relasi (JOIN) agar cepat.
 	// Tag `json:"-"` artinya field ini RAHASIA/HIDDEN dari API. Frontend tidak perlu tahu ID internal ini.
 	BoardInternalID int64 `json:"-" db:"board_internal_id"`
 }
+
+// BeforeCreate mengisi PublicID secara otomatis sebelum list disimpan.
+func (l *List) BeforeCreate(tx *gorm.DB) error {
+	if l.PublicID == uuid.Nil {
+		l.PublicID = uuid.New()
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"github.com/google/uuid"
 	"github.com/rakafajars/go-manajemen-project/models/types"
+	"gorm.io/gorm"
 )
 
 // ListPosition menyimpan urutan List di dalam sebuah Board.
@@ -10,13 +11,13 @@ import (
 // Cukup update satu baris di tabel ini yang berisi urutan ID-nya.
 type ListPosition struct {
 	// InternalId: Primary Key standar.
-	InternalId int64 `json:"internal_id" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+	InternalId int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
 
 	// PublicId: ID unik untuk API.
 	PublicId uuid.UUID `json:"public_id" db:"public_id"`
 
-	// BoardID: Menandakan posisi ini milik Board mana.
-	BoardID int64 `json:"board_id" db:"board_id" gorm:"column:board_id"`
+	// BoardID: Menandakan posisi ini milik Board mana (satu baris per board).
+	BoardID int64 `json:"-" db:"board_id" gorm:"column:board_id;uniqueIndex"`
 
 	// ListOrder: Berisi urutan ID List (UUID) dalam bentuk Array.
 	// Contoh isi: ["uuid-list-A", "uuid-list-B", "uuid-list-C"]
@@ -26,3 +27,14 @@ type ListPosition struct {
 	// GORM jadi tahu cara simpan array ini ke kolom PostgreSQL bertipe `uuid[]`.
 	ListOrder types.UUIDArray `json:"list_order" db:"list_order"`
 }
+
+// BeforeCreate mengisi PublicId dan memastikan ListOrder tidak NULL.
+func (p *ListPosition) BeforeCreate(tx *gorm.DB) error {
+	if p.PublicId == uuid.Nil {
+		p.PublicId = uuid.New()
+	}
+	if p.ListOrder == nil {
+		p.ListOrder = types.UUIDArray{}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package models
+
+// All mengembalikan satu instance dari setiap model yang punya tabel di database,
+// diurutkan mengikuti urutan tabel dibuat di databases/migrations.
+//
+// Dipakai oleh pengecek schema drift (databases.CheckDrift) untuk membandingkan model
+// dengan skema database. Struct hasil query/JOIN seperti BoardMemberDetail, CardAssigneeDetail,
+// dan AssignedCard tidak punya tabel sendiri sehingga tidak dimasukkan.
+//
+// Jika menambah model baru, tambahkan juga di sini.
+func All() []interface{} {
+	return []interface{}{
+		&User{},
+		&RefreshToken{},
+		&RevokedToken{},
+		&UserTokenRevocation{},
+		&Board{},
+		&BoardMember{},
+		&ListPosition{},
+		&BoardInvitation{},
+		&List{},
+		&Card{},
+		&CardPosition{},
+		&Comment{},
+		&CommentRevision{},
+		&CommentMention{},
+		&Label{},
+		&CardLabel{},
+		&CardAssignee{},
+		&CardAttachment{},
+		&Checklist{},
+		&ChecklistItem{},
+	}
+}
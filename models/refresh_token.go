@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken menyimpan refresh token "opaque" (string acak, bukan JWT) milik user.
+//
+// Yang disimpan di database hanya HASH dari token, bukan token aslinya.
+// Jadi walaupun database bocor, token tidak bisa langsung dipakai.
+//
+// Rotasi: setiap kali refresh token dipakai, token lama ditandai revoked
+// dan diganti token baru dengan FamilyID yang sama. Jika token lama dipakai lagi
+// (reuse), kemungkinan token sudah dicuri, sehingga SEMUA token satu family dicabut.
+type RefreshToken struct {
+	// InternalID: Primary Key.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey;autoIncrement"`
+
+	// FamilyID: Penanda "rantai" token hasil rotasi dari satu kali login yang sama.
+	FamilyID uuid.UUID `json:"family_id" db:"family_id" gorm:"type:uuid;not null;index"`
+
+	// UserID: Pemilik token (Foreign Key ke users.internal_id).
+	UserID int64 `json:"-" db:"user_internal_id" gorm:"column:user_internal_id;not null;index"`
+
+	// TokenHash: SHA-256 dari token asli (hex). Dicari saat endpoint refresh dipanggil.
+	TokenHash string `json:"-" db:"token_hash" gorm:"not null;uniqueIndex"`
+
+	// ExpiresAt: Batas waktu token boleh dipakai.
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at" gorm:"not null"`
+
+	// RevokedAt: Terisi jika token sudah dipakai (dirotasi) atau dicabut.
+	// Pointer (*time.Time) agar bisa bernilai NULL di database.
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+
+	// ReplacedByID: InternalID token pengganti hasil rotasi (NULL jika belum dirotasi).
+	ReplacedByID *int64 `json:"-" db:"replaced_by_internal_id" gorm:"column:replaced_by_internal_id"`
+
+	// CreatedAt: Waktu token dibuat.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
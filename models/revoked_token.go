@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevokedToken adalah daftar access token (JWT) yang sudah dicabut sebelum waktunya expired.
+// Setiap token punya claim "jti" yang unik, dan jti itulah yang disimpan di sini.
+//
+// Baris boleh dihapus setelah ExpiresAt lewat, karena token-nya sudah tidak valid dengan sendirinya.
+type RevokedToken struct {
+	// JTI: ID unik token (claim "jti"). Sekaligus Primary Key.
+	JTI uuid.UUID `json:"jti" db:"jti" gorm:"column:jti;type:uuid;primaryKey"`
+
+	// UserID: Pemilik token yang dicabut.
+	UserID int64 `json:"-" db:"user_internal_id" gorm:"column:user_internal_id;not null;index"`
+
+	// ExpiresAt: Waktu expired asli token (claim "exp").
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at" gorm:"not null;index"`
+
+	// RevokedAt: Kapan token dicabut.
+	RevokedAt time.Time `json:"revoked_at" db:"revoked_at" gorm:"not null;index"`
+}
@@ -90,3 +90,42 @@ func (a UUIDArray) Value() (driver.Value, error) {
 func (UUIDArray) GormDataType() string {
 	return "uuid[]"
 }
+
+// IndexOf mengembalikan posisi id di dalam array, atau -1 jika tidak ada.
+func (a UUIDArray) IndexOf(id uuid.UUID) int {
+	for i, u := range a {
+		if u == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Remove mengembalikan array BARU tanpa id tersebut.
+// Array asli tidak diubah, sehingga aman dipakai walau array asli masih dibaca di tempat lain.
+func (a UUIDArray) Remove(id uuid.UUID) UUIDArray {
+	result := make(UUIDArray, 0, len(a))
+	for _, u := range a {
+		if u != id {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+// Insert mengembalikan array BARU dengan id disisipkan di posisi index.
+// Index di luar jangkauan otomatis dipotong: index < 0 menjadi 0, index > panjang array menjadi paling akhir.
+func (a UUIDArray) Insert(index int, id uuid.UUID) UUIDArray {
+	if index < 0 {
+		index = 0
+	}
+	if index > len(a) {
+		index = len(a)
+	}
+
+	result := make(UUIDArray, 0, len(a)+1)
+	result = append(result, a[:index]...)
+	result = append(result, id)
+	result = append(result, a[index:]...)
+	return result
+}
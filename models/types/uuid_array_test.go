@@ -0,0 +1,170 @@
+package types
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// testIDs membuat n UUID dengan nilai yang mudah dibedakan (…0001, …0002, dst).
+func testIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i][15] = byte(i + 1)
+	}
+	return ids
+}
+
+func TestUUIDArrayInsert(t *testing.T) {
+	ids := testIDs(4)
+	a, b, c, x := ids[0], ids[1], ids[2], ids[3]
+
+	tests := []struct {
+		name  string
+		array UUIDArray
+		index int
+		want  UUIDArray
+	}{
+		{"into empty", UUIDArray{}, 0, UUIDArray{x}},
+		{"into nil", nil, 3, UUIDArray{x}},
+		{"at head", UUIDArray{a, b, c}, 0, UUIDArray{x, a, b, c}},
+		{"in the middle", UUIDArray{a, b, c}, 1, UUIDArray{a, x, b, c}},
+		{"at tail", UUIDArray{a, b, c}, 3, UUIDArray{a, b, c, x}},
+		{"negative index is clamped to head", UUIDArray{a, b, c}, -5, UUIDArray{x, a, b, c}},
+		{"index past the end is clamped to tail", UUIDArray{a, b, c}, 99, UUIDArray{a, b, c, x}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := slices.Clone(tt.array)
+			got := tt.array.Insert(tt.index, x)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Insert(%d) = %v, want %v", tt.index, got, tt.want)
+			}
+			if !slices.Equal(tt.array, original) {
+				t.Errorf("Insert modified the original array: %v, want %v", tt.array, original)
+			}
+		})
+	}
+}
+
+func TestUUIDArrayInsertDoesNotShareBackingArray(t *testing.T) {
+	ids := testIDs(4)
+	array := make(UUIDArray, 2, 10)
+	copy(array, ids[:2])
+
+	first := array.Insert(2, ids[2])
+	second := array.Insert(2, ids[3])
+	if first[2] != ids[2] || second[2] != ids[3] {
+		t.Errorf("Insert results share memory: first = %v, second = %v", first, second)
+	}
+}
+
+func TestUUIDArrayRemove(t *testing.T) {
+	ids := testIDs(4)
+	a, b, c, missing := ids[0], ids[1], ids[2], ids[3]
+
+	tests := []struct {
+		name  string
+		array UUIDArray
+		id    uuid.UUID
+		want  UUIDArray
+	}{
+		{"head", UUIDArray{a, b, c}, a, UUIDArray{b, c}},
+		{"middle", UUIDArray{a, b, c}, b, UUIDArray{a, c}},
+		{"tail", UUIDArray{a, b, c}, c, UUIDArray{a, b}},
+		{"missing id", UUIDArray{a, b, c}, missing, UUIDArray{a, b, c}},
+		{"duplicates are all removed", UUIDArray{a, b, a}, a, UUIDArray{b}},
+		{"empty", UUIDArray{}, a, UUIDArray{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := slices.Clone(tt.array)
+			got := tt.array.Remove(tt.id)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Remove = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(tt.array, original) {
+				t.Errorf("Remove modified the original array: %v, want %v", tt.array, original)
+			}
+		})
+	}
+}
+
+func TestUUIDArrayIndexOf(t *testing.T) {
+	ids := testIDs(4)
+	array := UUIDArray{ids[0], ids[1], ids[2]}
+
+	tests := []struct {
+		id   uuid.UUID
+		want int
+	}{
+		{ids[0], 0},
+		{ids[1], 1},
+		{ids[2], 2},
+		{ids[3], -1},
+		{uuid.Nil, -1},
+	}
+
+	for _, tt := range tests {
+		if got := array.IndexOf(tt.id); got != tt.want {
+			t.Errorf("IndexOf(%s) = %d, want %d", tt.id, got, tt.want)
+		}
+	}
+	if got := UUIDArray(nil).IndexOf(ids[0]); got != -1 {
+		t.Errorf("nil IndexOf = %d, want -1", got)
+	}
+}
+
+func TestUUIDArrayScan(t *testing.T) {
+	ids := testIDs(2)
+	a, b := ids[0], ids[1]
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    UUIDArray
+		wantErr bool
+	}{
+		{"empty array", "{}", UUIDArray{}, false},
+		{"string", "{" + a.String() + "," + b.String() + "}", UUIDArray{a, b}, false},
+		{"bytes", []byte("{" + a.String() + "}"), UUIDArray{a}, false},
+		{"quoted", `{"` + a.String() + `","` + b.String() + `"}`, UUIDArray{a, b}, false},
+		{"invalid uuid", "{not-a-uuid}", nil, true},
+		{"unsupported type", 42, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got UUIDArray
+			err := got.Scan(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(got, tt.want) {
+				t.Errorf("Scan(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUUIDArrayValueRoundTrip(t *testing.T) {
+	ids := testIDs(3)
+
+	for _, array := range []UUIDArray{nil, {}, {ids[0]}, {ids[0], ids[1], ids[2]}} {
+		value, err := array.Value()
+		if err != nil {
+			t.Fatalf("Value(%v) returned error: %v", array, err)
+		}
+
+		var got UUIDArray
+		if err := got.Scan(value); err != nil {
+			t.Fatalf("Scan(%v) returned error: %v", value, err)
+		}
+		if !slices.Equal(got, array) {
+			t.Errorf("round trip of %v = %v", array, got)
+		}
+	}
+}
@@ -13,7 +13,8 @@ type User struct {
 	// InternalID: ID utama untuk database (Primary Key).
 	// Menggunakan int64 agar performa indexing dan relasi database lebih cepat.
 	// Tag `gorm:"primaryKey"` memberi tahu GORM bahwa ini adalah kunci utama tabel.
-	InternalID int64 `json:"internal_id" db:"internal_id" gorm:"primaryKey"`
+	// Tag `json:"-"` menyembunyikan ID internal dari API, client cukup tahu PublicID.
+	InternalID int64 `json:"-" db:"internal_id" gorm:"primaryKey"`
 
 	// PublicID: ID unik yang aman untuk ditampilkan ke publik/API.
 	// Menggunakan UUID agar ID tidak berurutan dan sulit ditebak orang lain.
@@ -28,7 +29,8 @@ type User struct {
 	Email string `json:"email" db:"email" gorm:"unique"`
 
 	// Password: Password yang sudah di-hash (bukan plain text).
-	Password string `json:"password" db:"password" gorm:"column:password"`
+	// Tag `json:"-"` WAJIB: hash password tidak boleh pernah ikut terkirim di response API.
+	Password string `json:"-" db:"password" gorm:"column:password"`
 
 	// Role: Peran pengguna, misal "admin" atau "user".
 	Role string `json:"role" db:"role"`
@@ -46,3 +48,13 @@ type User struct {
 	// Tag `json:"-"` berarti field ini TIDAK akan dimunculkan saat data diubah jadi JSON (disembunyikan dari API).
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
+
+// BeforeCreate adalah "hook" GORM yang otomatis dipanggil sebelum INSERT.
+// Kita pakai untuk mengisi PublicID jika belum diisi, karena uuid.UUID kosong
+// (00000000-0000-...) akan ikut tersimpan dan bentrok dengan constraint UNIQUE.
+func (u *User) BeforeCreate(tx *gorm.DB) error {
+	if u.PublicID == uuid.Nil {
+		u.PublicID = uuid.New()
+	}
+	return nil
+}
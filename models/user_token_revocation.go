@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// UserTokenRevocation menyimpan batas waktu pencabutan SEMUA token milik seorang user.
+// Access token yang "iat"-nya (waktu dibuat) tidak lebih baru dari RevokedBefore dianggap tidak valid.
+//
+// Dipakai untuk "logout semua sesi" dan pencabutan token oleh admin,
+// karena kita tidak menyimpan daftar semua jti yang pernah diterbitkan.
+type UserTokenRevocation struct {
+	// UserID: Primary Key sekaligus Foreign Key ke users.internal_id (satu baris per user).
+	UserID int64 `json:"-" db:"user_internal_id" gorm:"column:user_internal_id;primaryKey"`
+
+	// RevokedBefore: Token dengan iat <= waktu ini ditolak.
+	RevokedBefore time.Time `json:"revoked_before" db:"revoked_before" gorm:"not null"`
+
+	// UpdatedAt: Kapan batas ini terakhir diubah. Dipakai untuk sinkronisasi cache.
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" gorm:"index"`
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvitationNotPending dikembalikan saat undangan sudah direspon lebih dulu
+// (misal diterima di satu tab lalu ditolak di tab lain).
+var ErrInvitationNotPending = errors.New("invitation is no longer pending")
+
+// BoardInvitationRepository adalah kontrak operasi database untuk tabel board_invitations.
+type BoardInvitationRepository interface {
+	Create(invitation *models.BoardInvitation) error
+	FindByTokenHash(hash string) (*models.BoardInvitation, error)
+	FindByPublicID(publicID uuid.UUID) (*models.BoardInvitation, error)
+	FindPendingByBoard(boardID int64) ([]models.BoardInvitation, error)
+	FindPendingByEmail(email string) ([]models.BoardInvitation, error)
+	RevokePendingByEmail(boardID int64, email string) error
+	Respond(invitation *models.BoardInvitation, status string) error
+	Accept(invitation *models.BoardInvitation, member *models.BoardMember) error
+}
+
+type boardInvitationRepository struct{}
+
+// NewBoardInvitationRepository membuat instance BoardInvitationRepository baru.
+func NewBoardInvitationRepository() BoardInvitationRepository {
+	return &boardInvitationRepository{}
+}
+
+// Create menyimpan undangan baru.
+func (r *boardInvitationRepository) Create(invitation *models.BoardInvitation) error {
+	return config.DB.Create(invitation).Error
+}
+
+// FindByTokenHash mencari undangan berdasarkan hash token-nya.
+func (r *boardInvitationRepository) FindByTokenHash(hash string) (*models.BoardInvitation, error) {
+	var invitation models.BoardInvitation
+	err := config.DB.Where("token_hash = ?", hash).First(&invitation).Error
+	return &invitation, err
+}
+
+// FindByPublicID mencari undangan berdasarkan PublicID.
+func (r *boardInvitationRepository) FindByPublicID(publicID uuid.UUID) (*models.BoardInvitation, error) {
+	var invitation models.BoardInvitation
+	err := config.DB.Where("public_id = ?", publicID).First(&invitation).Error
+	return &invitation, err
+}
+
+// FindPendingByBoard mengambil undangan yang masih menunggu jawaban dan belum expired.
+func (r *boardInvitationRepository) FindPendingByBoard(boardID int64) ([]models.BoardInvitation, error) {
+	var invitations []models.BoardInvitation
+	err := config.DB.
+		Where("board_internal_id = ? AND status = ? AND expires_at > ?", boardID, models.InvitationStatusPending, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// FindPendingByEmail mengambil undangan untuk sebuah email (di semua board) yang masih
+// menunggu jawaban dan belum expired.
+func (r *boardInvitationRepository) FindPendingByEmail(email string) ([]models.BoardInvitation, error) {
+	var invitations []models.BoardInvitation
+	err := config.DB.
+		Where("email = ? AND status = ? AND expires_at > ?", email, models.InvitationStatusPending, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// RevokePendingByEmail mencabut undangan lama yang masih pending untuk email yang sama,
+// sehingga hanya undangan terbaru yang berlaku.
+func (r *boardInvitationRepository) RevokePendingByEmail(boardID int64, email string) error {
+	return config.DB.Model(&models.BoardInvitation{}).
+		Where("board_internal_id = ? AND email = ? AND status = ?", boardID, email, models.InvitationStatusPending).
+		Updates(map[string]interface{}{
+			"status":       models.InvitationStatusRevoked,
+			"responded_at": time.Now(),
+		}).Error
+}
+
+// Respond mengubah status undangan yang masih pending (decline/revoke).
+func (r *boardInvitationRepository) Respond(invitation *models.BoardInvitation, status string) error {
+	return respondInvitation(config.DB, invitation, status)
+}
+
+// Accept menerima undangan: status diubah menjadi accepted dan user dimasukkan
+// sebagai member board, dalam SATU transaksi.
+func (r *boardInvitationRepository) Accept(invitation *models.BoardInvitation, member *models.BoardMember) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := respondInvitation(tx, invitation, models.InvitationStatusAccepted); err != nil {
+			return err
+		}
+
+		// ON CONFLICT DO NOTHING: jika user ternyata sudah member, cukup abaikan.
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(member).Error
+	})
+}
+
+// respondInvitation meng-update status hanya jika undangan masih pending.
+// Kondisi di WHERE mencegah dua respon bersamaan sama-sama "berhasil".
+func respondInvitation(db *gorm.DB, invitation *models.BoardInvitation, status string) error {
+	now := time.Now()
+	result := db.Model(&models.BoardInvitation{}).
+		Where("internal_id = ? AND status = ?", invitation.InternalID, models.InvitationStatusPending).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvitationNotPending
+	}
+
+	invitation.Status = status
+	invitation.RespondedAt = &now
+	return nil
+}
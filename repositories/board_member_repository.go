@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+)
+
+// BoardMemberRepository adalah kontrak operasi database untuk tabel board_members.
+type BoardMemberRepository interface {
+	ListByBoard(boardID int64) ([]models.BoardMemberDetail, error)
+	Find(boardID, userID int64) (*models.BoardMember, error)
+	UpdateRole(boardID, userID int64, role string) error
+	Remove(boardID, userID int64) error
+}
+
+type boardMemberRepository struct{}
+
+// NewBoardMemberRepository membuat instance BoardMemberRepository baru.
+func NewBoardMemberRepository() BoardMemberRepository {
+	return &boardMemberRepository{}
+}
+
+// ListByBoard mengambil semua member board beserta data user-nya (JOIN ke users).
+func (r *boardMemberRepository) ListByBoard(boardID int64) ([]models.BoardMemberDetail, error) {
+	var members []models.BoardMemberDetail
+	err := config.DB.Table("board_members bm").
+		Select("u.internal_id AS user_internal_id, u.public_id AS user_public_id, u.name, u.email, bm.role, bm.joined_at").
+		Joins("JOIN users u ON u.internal_id = bm.user_internal_id").
+		Where("bm.board_internal_id = ? AND u.deleted_at IS NULL", boardID).
+		Order("bm.joined_at ASC").
+		Scan(&members).Error
+	return members, err
+}
+
+// Find mencari keanggotaan user di board tertentu.
+func (r *boardMemberRepository) Find(boardID, userID int64) (*models.BoardMember, error) {
+	var member models.BoardMember
+	err := config.DB.
+		Where("board_internal_id = ? AND user_internal_id = ?", boardID, userID).
+		First(&member).Error
+	return &member, err
+}
+
+// UpdateRole mengubah role member di board.
+func (r *boardMemberRepository) UpdateRole(boardID, userID int64, role string) error {
+	return config.DB.Model(&models.BoardMember{}).
+		Where("board_internal_id = ? AND user_internal_id = ?", boardID, userID).
+		Update("role", role).Error
+}
+
+// Remove mengeluarkan user dari board sekaligus melepasnya dari semua kartu dan item checklist
+// di board itu, karena hanya member yang boleh menjadi assignee.
+func (r *boardMemberRepository) Remove(boardID, userID int64) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		boardCards := tx.Table("cards c").
+			Select("c.internal_id").
+			Joins("JOIN lists l ON l.internal_id = c.list_id").
+			Where("l.board_internal_id = ?", boardID)
+
+		if err := tx.
+			Where("user_internal_id = ?", userID).
+			Where("card_internal_id IN (?)", boardCards).
+			Delete(&models.CardAssignee{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.ChecklistItem{}).
+			Where("assignee_internal_id = ?", userID).
+			Where("card_internal_id IN (?)", boardCards).
+			Updates(map[string]interface{}{"assignee_internal_id": nil, "assignee_public_id": nil}).Error; err != nil {
+			return err
+		}
+
+		return tx.
+			Where("board_internal_id = ? AND user_internal_id = ?", boardID, userID).
+			Delete(&models.BoardMember{}).Error
+	})
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+)
+
+// BoardRepository adalah kontrak operasi database untuk tabel boards.
+type BoardRepository interface {
+	CreateWithOwner(board *models.Board) error
+	FindByPublicID(publicID uuid.UUID) (*models.Board, error)
+	FindByID(id int64) (*models.Board, error)
+	FindAllByMember(userID int64, page, limit int) ([]models.Board, int64, error)
+	Update(board *models.Board) error
+	Delete(board *models.Board) error
+}
+
+type boardRepository struct{}
+
+// NewBoardRepository membuat instance BoardRepository baru.
+func NewBoardRepository() BoardRepository {
+	return &boardRepository{}
+}
+
+// CreateWithOwner menyimpan board baru dalam SATU transaksi bersama:
+//  1. Pembuat board sebagai models.BoardMember pertama (role owner)
+//  2. Baris models.ListPosition kosong (urutan list belum ada)
+//
+// Jika salah satu gagal, semuanya di-rollback sehingga tidak ada board "setengah jadi".
+func (r *boardRepository) CreateWithOwner(board *models.Board) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(board).Error; err != nil {
+			return err
+		}
+
+		member := models.BoardMember{
+			BoardID:  board.InternalID,
+			UserID:   board.OwnerID,
+			Role:     models.BoardRoleOwner,
+			JoinedAt: time.Now(),
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			return err
+		}
+
+		position := models.ListPosition{BoardID: board.InternalID}
+		return tx.Create(&position).Error
+	})
+}
+
+// FindByPublicID mencari board berdasarkan PublicID.
+func (r *boardRepository) FindByPublicID(publicID uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	err := config.DB.Where("public_id = ?", publicID).First(&board).Error
+	return &board, err
+}
+
+// FindByID mencari board berdasarkan InternalID.
+func (r *boardRepository) FindByID(id int64) (*models.Board, error) {
+	var board models.Board
+	err := config.DB.First(&board, id).Error
+	return &board, err
+}
+
+// FindAllByMember mengambil board di mana user menjadi member, dengan pagination.
+// Mengembalikan data halaman ini beserta total seluruh data (untuk PaginationMeta).
+func (r *boardRepository) FindAllByMember(userID int64, page, limit int) ([]models.Board, int64, error) {
+	var (
+		boards []models.Board
+		total  int64
+	)
+
+	query := config.ReadDB().Model(&models.Board{}).
+		Joins("JOIN board_members bm ON bm.board_internal_id = boards.internal_id").
+		Where("bm.user_internal_id = ?", userID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("boards.created_at DESC").Offset(offset).Limit(limit).Find(&boards).Error
+	return boards, total, err
+}
+
+// Update menyimpan perubahan field board (title, description, due_date).
+// Select dipakai agar nilai kosong/NULL tetap ikut tersimpan (Updates biasa mengabaikan zero value).
+func (r *boardRepository) Update(board *models.Board) error {
+	return config.DB.Model(board).
+		Select("title", "description", "due_date", "updated_at").
+		Updates(board).Error
+}
+
+// Delete menghapus board. Member, list, dan data turunan lainnya ikut terhapus
+// lewat foreign key ON DELETE CASCADE di database.
+func (r *boardRepository) Delete(board *models.Board) error {
+	return config.DB.Delete(board).Error
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm/clause"
+)
+
+// CardAssigneeRepository adalah kontrak operasi database untuk tabel card_assignees.
+type CardAssigneeRepository interface {
+	ListByCard(cardID int64) ([]models.CardAssigneeDetail, error)
+	Assign(cardID, userID int64) error
+	Unassign(cardID, userID int64) error
+	FindAssignedCards(userID int64) ([]models.AssignedCard, error)
+}
+
+type cardAssigneeRepository struct{}
+
+// NewCardAssigneeRepository membuat instance CardAssigneeRepository baru.
+func NewCardAssigneeRepository() CardAssigneeRepository {
+	return &cardAssigneeRepository{}
+}
+
+// ListByCard mengambil semua assignee kartu beserta data user-nya (JOIN ke users).
+func (r *cardAssigneeRepository) ListByCard(cardID int64) ([]models.CardAssigneeDetail, error) {
+	var assignees []models.CardAssigneeDetail
+	err := config.DB.Table("card_assignees ca").
+		Select("u.public_id AS user_public_id, u.name, u.email, ca.assigned_at").
+		Joins("JOIN users u ON u.internal_id = ca.user_internal_id").
+		Where("ca.card_internal_id = ? AND u.deleted_at IS NULL", cardID).
+		Order("ca.assigned_at ASC").
+		Scan(&assignees).Error
+	return assignees, err
+}
+
+// Assign menugaskan user ke kartu. Jika sudah ditugaskan, tidak terjadi apa-apa (idempotent).
+func (r *cardAssigneeRepository) Assign(cardID, userID int64) error {
+	return config.DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.CardAssignee{CardID: cardID, UserID: userID}).Error
+}
+
+// Unassign melepas user dari kartu.
+func (r *cardAssigneeRepository) Unassign(cardID, userID int64) error {
+	return config.DB.
+		Where("card_internal_id = ? AND user_internal_id = ?", cardID, userID).
+		Delete(&models.CardAssignee{}).Error
+}
+
+// FindAssignedCards mengambil semua kartu yang ditugaskan ke user, diurutkan per board
+// lalu per due date (kartu tanpa due date di akhir).
+//
+// JOIN ke board_members memastikan kartu dari board yang sudah tidak ia ikuti tidak muncul,
+// dan kartu yang sudah selesai (due_complete) tidak lagi dihitung sebagai beban kerja.
+func (r *cardAssigneeRepository) FindAssignedCards(userID int64) ([]models.AssignedCard, error) {
+	var cards []models.AssignedCard
+	err := config.ReadDB().Table("card_assignees ca").
+		Select(`c.public_id AS card_public_id, c.title, c.due_date, c.start_date, c.priority,
+			l.public_id AS list_public_id, l.title AS list_title,
+			b.public_id AS board_public_id, b.title AS board_title,
+			ca.assigned_at`).
+		Joins("JOIN cards c ON c.internal_id = ca.card_internal_id").
+		Joins("JOIN lists l ON l.internal_id = c.list_id").
+		Joins("JOIN boards b ON b.internal_id = l.board_internal_id").
+		Joins("JOIN board_members bm ON bm.board_internal_id = b.internal_id AND bm.user_internal_id = ca.user_internal_id").
+		Where("ca.user_internal_id = ? AND c.due_complete = false", userID).
+		Order("b.title ASC, b.internal_id ASC, c.due_date ASC NULLS LAST, c.title ASC").
+		Scan(&cards).Error
+	return cards, err
+}
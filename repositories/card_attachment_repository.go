@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+)
+
+// CardAttachmentRepository adalah kontrak operasi database untuk tabel card_attachments.
+// Repository ini hanya mengurus metadata; isi file diurus package storage.
+type CardAttachmentRepository interface {
+	Create(attachment *models.CardAttachment) error
+	FindByPublicID(publicID uuid.UUID) (*models.CardAttachment, error)
+	FindByCard(cardID int64) ([]models.CardAttachment, error)
+	FindByList(listID int64) ([]models.CardAttachment, error)
+	FindByBoard(boardID int64) ([]models.CardAttachment, error)
+	Delete(attachment *models.CardAttachment) error
+	FindPendingThumbnails(limit int) ([]models.CardAttachment, error)
+	UpdateThumbnail(attachment *models.CardAttachment) error
+}
+
+type cardAttachmentRepository struct{}
+
+// NewCardAttachmentRepository membuat instance CardAttachmentRepository baru.
+func NewCardAttachmentRepository() CardAttachmentRepository {
+	return &cardAttachmentRepository{}
+}
+
+// Create menyimpan metadata attachment baru.
+func (r *cardAttachmentRepository) Create(attachment *models.CardAttachment) error {
+	return config.DB.Create(attachment).Error
+}
+
+// FindByPublicID mencari attachment berdasarkan PublicID.
+func (r *cardAttachmentRepository) FindByPublicID(publicID uuid.UUID) (*models.CardAttachment, error) {
+	var attachment models.CardAttachment
+	err := config.DB.Where("public_id = ?", publicID).First(&attachment).Error
+	return &attachment, err
+}
+
+// FindByCard mengambil semua attachment kartu, dari yang paling baru.
+func (r *cardAttachmentRepository) FindByCard(cardID int64) ([]models.CardAttachment, error) {
+	var attachments []models.CardAttachment
+	err := config.DB.Where("card_internal_id = ?", cardID).
+		Order("created_at DESC, internal_id DESC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// FindByList mengambil semua attachment dari kartu-kartu di list.
+func (r *cardAttachmentRepository) FindByList(listID int64) ([]models.CardAttachment, error) {
+	listCards := config.DB.Model(&models.Card{}).Select("internal_id").Where("list_id = ?", listID)
+
+	var attachments []models.CardAttachment
+	err := config.DB.Where("card_internal_id IN (?)", listCards).Find(&attachments).Error
+	return attachments, err
+}
+
+// FindByBoard mengambil semua attachment dari kartu-kartu di board.
+func (r *cardAttachmentRepository) FindByBoard(boardID int64) ([]models.CardAttachment, error) {
+	boardCards := config.DB.Table("cards c").
+		Select("c.internal_id").
+		Joins("JOIN lists l ON l.internal_id = c.list_id").
+		Where("l.board_internal_id = ?", boardID)
+
+	var attachments []models.CardAttachment
+	err := config.DB.Where("card_internal_id IN (?)", boardCards).Find(&attachments).Error
+	return attachments, err
+}
+
+// Delete menghapus metadata attachment.
+func (r *cardAttachmentRepository) Delete(attachment *models.CardAttachment) error {
+	return config.DB.Delete(attachment).Error
+}
+
+// FindPendingThumbnails mengambil attachment yang thumbnail-nya belum dibuat, dari yang paling lama.
+func (r *cardAttachmentRepository) FindPendingThumbnails(limit int) ([]models.CardAttachment, error) {
+	var attachments []models.CardAttachment
+	err := config.DB.Where("thumbnail_status = ?", models.ThumbnailStatusPending).
+		Order("internal_id ASC").
+		Limit(limit).
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// UpdateThumbnail menyimpan hasil thumbnail worker (dimensi, key, dan status thumbnail).
+// Mengembalikan gorm.ErrRecordNotFound jika attachment sudah dihapus atau sudah diproses
+// oleh worker lain, sehingga pemanggil tahu thumbnail yang baru dibuat tidak terpakai.
+func (r *cardAttachmentRepository) UpdateThumbnail(attachment *models.CardAttachment) error {
+	result := config.DB.Model(attachment).
+		Where("thumbnail_status = ?", models.ThumbnailStatusPending).
+		Select("width", "height", "thumbnail_key", "thumbnail_status").
+		Updates(attachment)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
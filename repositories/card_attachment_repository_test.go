@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+)
+
+func TestCardAttachmentRepositoryFindByListAndBoard(t *testing.T) {
+	openTestDB(t)
+	list := createTestList(t)
+	otherList := &models.List{Title: "Other", BoardInternalID: list.BoardInternalID, BoardPublicID: list.BoardPublicID}
+	if err := config.DB.Create(otherList).Error; err != nil {
+		t.Fatalf("create list: %v", err)
+	}
+	unrelatedList := createTestList(t)
+
+	cards := NewCardRepository()
+	attachments := NewCardAttachmentRepository()
+	attach := func(list *models.List, file string) {
+		t.Helper()
+		card := &models.Card{Title: file, ListID: list.InternalID, ListPublicID: list.PublicID}
+		if err := cards.Create(card, -1); err != nil {
+			t.Fatalf("create card: %v", err)
+		}
+		var board models.Board
+		if err := config.DB.First(&board, list.BoardInternalID).Error; err != nil {
+			t.Fatalf("find board: %v", err)
+		}
+		attachment := &models.CardAttachment{
+			PublicID:     uuid.New(),
+			File:         file,
+			FileName:     file,
+			ContentType:  "text/plain",
+			Size:         1,
+			UserID:       board.OwnerID,
+			UserPublicID: board.OwnerPublicID,
+			CardID:       card.InternalId,
+			CardPublicID: card.PublicId,
+		}
+		if err := attachments.Create(attachment); err != nil {
+			t.Fatalf("create attachment: %v", err)
+		}
+	}
+
+	attach(list, "a")
+	attach(list, "b")
+	attach(otherList, "c")
+	attach(unrelatedList, "d")
+
+	files := func(found []models.CardAttachment, err error) []string {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("find attachments: %v", err)
+		}
+		var names []string
+		for _, attachment := range found {
+			names = append(names, attachment.File)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	if got := files(attachments.FindByList(list.InternalID)); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("FindByList = %q, want [a b]", got)
+	}
+	if got := files(attachments.FindByBoard(list.BoardInternalID)); len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Errorf("FindByBoard = %q, want [a b c]", got)
+	}
+}
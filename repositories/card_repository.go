@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCardMovedConcurrently dikembalikan Move/Delete jika kartu ternyata sudah dipindah
+// ke list lain oleh request lain sejak kartu dibaca. Client cukup mengulang request.
+var ErrCardMovedConcurrently = errors.New("card was moved by another request")
+
+// CardRepository adalah kontrak operasi database untuk tabel cards.
+//
+// Urutan kartu di sebuah list disimpan di models.CardPosition.CardOrder, sedangkan
+// models.Card.Position adalah salinan index-nya. Setiap operasi yang mengubah urutan
+// memperbarui keduanya di transaksi yang sama.
+type CardRepository interface {
+	Create(card *models.Card, position int) error
+	FindByPublicID(publicID uuid.UUID) (*models.Card, error)
+	FindByListOrdered(listID int64) ([]models.Card, error)
+	FindByBoard(boardID int64, labelIDs []int64, matchAll bool) ([]models.Card, error)
+	Update(card *models.Card) error
+	Delete(card *models.Card) error
+	Move(card *models.Card, target *models.List, position int) error
+}
+
+type cardRepository struct{}
+
+// NewCardRepository membuat instance CardRepository baru.
+func NewCardRepository() CardRepository {
+	return &cardRepository{}
+}
+
+// Create menyimpan kartu baru dan menyisipkannya ke CardOrder di index position.
+// position < 0 berarti "paling bawah".
+func (r *cardRepository) Create(card *models.Card, position int) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		cardPosition, err := lockCardPosition(tx, card.ListID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Create(card).Error; err != nil {
+			return err
+		}
+
+		if position < 0 {
+			position = len(cardPosition.CardOrder)
+		}
+		cardPosition.CardOrder = cardPosition.CardOrder.Insert(position, card.PublicId)
+		if err := saveCardOrder(tx, cardPosition); err != nil {
+			return err
+		}
+		// Kartu baru disimpan dengan position 0 dan kartu sesudahnya ikut bergeser,
+		// jadi kolom position seluruh list disinkronkan ulang.
+		if err := syncCardPositions(tx, card.ListID); err != nil {
+			return err
+		}
+
+		card.Position = cardPosition.CardOrder.IndexOf(card.PublicId)
+		return nil
+	})
+}
+
+// FindByPublicID mencari kartu berdasarkan PublicId.
+func (r *cardRepository) FindByPublicID(publicID uuid.UUID) (*models.Card, error) {
+	var card models.Card
+	err := config.DB.Where("public_id = ?", publicID).First(&card).Error
+	return &card, err
+}
+
+// FindByListOrdered mengambil semua kartu di list, diurutkan berdasarkan kolom position
+// (yang selalu disinkronkan dengan CardOrder).
+func (r *cardRepository) FindByListOrdered(listID int64) ([]models.Card, error) {
+	var cards []models.Card
+	err := config.DB.Where("list_id = ?", listID).
+		Order("position ASC, created_at ASC").
+		Find(&cards).Error
+	return cards, err
+}
+
+// FindByBoard mengambil kartu di semua list board, opsional difilter label.
+//
+// labelIDs kosong berarti tanpa filter. Jika matchAll true, hanya kartu yang punya
+// SEMUA label yang dikembalikan; jika false, cukup salah satunya.
+func (r *cardRepository) FindByBoard(boardID int64, labelIDs []int64, matchAll bool) ([]models.Card, error) {
+	query := config.ReadDB().Model(&models.Card{}).
+		Select("cards.*").
+		Joins("JOIN lists l ON l.internal_id = cards.list_id").
+		Where("l.board_internal_id = ?", boardID)
+
+	if len(labelIDs) > 0 {
+		query = query.
+			Joins("JOIN card_labels cl ON cl.card_internal_id = cards.internal_id").
+			Where("cl.label_internal_id IN ?", labelIDs).
+			Group("cards.internal_id")
+		if matchAll {
+			query = query.Having("COUNT(DISTINCT cl.label_internal_id) = ?", len(labelIDs))
+		}
+	}
+
+	var cards []models.Card
+	err := query.
+		Order("cards.list_id ASC, cards.rank ASC, cards.position ASC, cards.internal_id ASC").
+		Find(&cards).Error
+	return cards, err
+}
+
+// Update menyimpan perubahan isi kartu (bukan posisinya).
+func (r *cardRepository) Update(card *models.Card) error {
+	card.UpdatedAt = time.Now()
+	return config.DB.Model(card).
+		Select("title", "description", "due_date", "start_date", "due_complete", "completed_at",
+			"priority", "estimate", "cover_attachment_internal_id", "cover_attachment_public_id", "updated_at").
+		Updates(card).Error
+}
+
+// Delete menghapus kartu dan mengeluarkan ID-nya dari CardOrder.
+func (r *cardRepository) Delete(card *models.Card) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		cardPosition, err := lockCardPosition(tx, card.ListID)
+		if err != nil {
+			return err
+		}
+		if err := ensureCardInList(tx, card); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(card).Error; err != nil {
+			return err
+		}
+
+		cardPosition.CardOrder = cardPosition.CardOrder.Remove(card.PublicId)
+		if err := saveCardOrder(tx, cardPosition); err != nil {
+			return err
+		}
+		return syncCardPositions(tx, card.ListID)
+	})
+}
+
+// Move memindahkan kartu ke list target di index position, secara atomik.
+//
+// CardOrder list asal dan list tujuan di-lock (SELECT ... FOR UPDATE) dalam urutan
+// ListID dari kecil ke besar. Urutan lock yang selalu sama mencegah deadlock saat dua user
+// memindahkan kartu ke arah berlawanan (A->B dan B->A) di waktu bersamaan.
+func (r *cardRepository) Move(card *models.Card, target *models.List, position int) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		positions, err := lockCardPositions(tx, card.ListID, target.InternalID)
+		if err != nil {
+			return err
+		}
+		// Lock diambil dulu baru kartu dicek ulang, sehingga urutan lock selalu
+		// card_positions -> cards seperti di Create dan Delete.
+		if err := ensureCardInList(tx, card); err != nil {
+			return err
+		}
+		source := positions[card.ListID]
+		destination := positions[target.InternalID]
+
+		source.CardOrder = source.CardOrder.Remove(card.PublicId)
+		if source.InternalID == destination.InternalID {
+			destination.CardOrder = source.CardOrder
+		} else {
+			destination.CardOrder = destination.CardOrder.Remove(card.PublicId)
+			if err := saveCardOrder(tx, source); err != nil {
+				return err
+			}
+		}
+		destination.CardOrder = destination.CardOrder.Insert(position, card.PublicId)
+		if err := saveCardOrder(tx, destination); err != nil {
+			return err
+		}
+
+		if card.ListID != target.InternalID {
+			if err := tx.Model(&models.Card{InternalId: card.InternalId}).Updates(map[string]interface{}{
+				"list_id":        target.InternalID,
+				"list_public_id": target.PublicID,
+				"updated_at":     time.Now(),
+			}).Error; err != nil {
+				return err
+			}
+			if err := syncCardPositions(tx, source.ListID); err != nil {
+				return err
+			}
+		}
+		if err := syncCardPositions(tx, target.InternalID); err != nil {
+			return err
+		}
+
+		card.ListID = target.InternalID
+		card.ListPublicID = target.PublicID
+		card.Position = destination.CardOrder.IndexOf(card.PublicId)
+		return nil
+	})
+}
+
+// ensureCardInList memastikan kartu masih berada di list yang CardOrder-nya sudah di-lock.
+// Semua pemindahan kartu wajib memegang lock list asal, jadi hasil cek ini tetap valid
+// sampai transaksi selesai.
+func ensureCardInList(tx *gorm.DB, card *models.Card) error {
+	var listID int64
+	if err := tx.Model(&models.Card{}).
+		Where("internal_id = ?", card.InternalId).
+		Pluck("list_id", &listID).Error; err != nil {
+		return err
+	}
+	if listID != card.ListID {
+		return ErrCardMovedConcurrently
+	}
+	return nil
+}
+
+// lockCardPositions me-lock CardPosition beberapa list sekaligus dengan urutan ListID menaik.
+func lockCardPositions(tx *gorm.DB, listIDs ...int64) (map[int64]*models.CardPosition, error) {
+	sort.Slice(listIDs, func(i, j int) bool { return listIDs[i] < listIDs[j] })
+
+	result := make(map[int64]*models.CardPosition, len(listIDs))
+	for _, listID := range listIDs {
+		if _, locked := result[listID]; locked {
+			continue
+		}
+		cardPosition, err := lockCardPosition(tx, listID)
+		if err != nil {
+			return nil, err
+		}
+		result[listID] = cardPosition
+	}
+	return result, nil
+}
+
+// lockCardPosition mengambil baris CardPosition milik list dengan "SELECT ... FOR UPDATE".
+// Jika barisnya belum ada (list lama), baris kosong dibuat dulu.
+// Penjelasan lengkap tentang lock ada di lockListPosition.
+func lockCardPosition(tx *gorm.DB, listID int64) (*models.CardPosition, error) {
+	var cardPosition models.CardPosition
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("list_internal_id = ?", listID).
+		First(&cardPosition).Error
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &cardPosition, err
+	}
+
+	empty := models.CardPosition{ListID: listID}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&empty).Error; err != nil {
+		return nil, err
+	}
+
+	cardPosition = models.CardPosition{}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("list_internal_id = ?", listID).
+		First(&cardPosition).Error
+	return &cardPosition, err
+}
+
+// saveCardOrder menyimpan kolom card_order saja.
+func saveCardOrder(tx *gorm.DB, cardPosition *models.CardPosition) error {
+	return tx.Model(cardPosition).Update("card_order", cardPosition.CardOrder).Error
+}
+
+// syncCardPositions menyalin index setiap kartu di CardOrder ke kolom cards.position
+// dengan SATU query UPDATE (array_position di PostgreSQL dimulai dari 1, jadi dikurangi 1).
+func syncCardPositions(tx *gorm.DB, listID int64) error {
+	return tx.Exec(`
+		UPDATE cards
+		SET position = COALESCE(array_position(cp.card_order, cards.public_id) - 1, cards.position)
+		FROM card_positions cp
+		WHERE cp.list_internal_id = cards.list_id
+		  AND cards.list_id = ?`, listID).Error
+}
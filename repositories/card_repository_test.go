@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/databases"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB menghubungkan config.DB ke database di TEST_DATABASE_URL lalu menjalankan
+// semua migration. Test dilewati jika TEST_DATABASE_URL kosong.
+//
+// Pakai database khusus test: data yang dibuat test tidak dihapus, tapi setiap test
+// membuat user, board, dan list sendiri sehingga tidak saling mengganggu.
+func openTestDB(t *testing.T) {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	migrator, err := databases.NewMigrator(sqlDB)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background(), 0); err != nil {
+		t.Fatalf("run migrations: %v", err)
+	}
+
+	previous := config.DB
+	config.DB = db
+	t.Cleanup(func() {
+		config.DB = previous
+		sqlDB.Close()
+	})
+}
+
+// createTestList membuat user, board, dan satu list kosong di dalamnya.
+func createTestList(t *testing.T) *models.List {
+	t.Helper()
+
+	user := &models.User{Name: "Test", Email: uuid.NewString() + "@example.com", Password: "x", Role: "user"}
+	if err := config.DB.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	board := &models.Board{Title: "Board", OwnerID: user.InternalID, OwnerPublicID: user.PublicID}
+	if err := config.DB.Create(board).Error; err != nil {
+		t.Fatalf("create board: %v", err)
+	}
+	list := &models.List{Title: "List", BoardInternalID: board.InternalID, BoardPublicID: board.PublicID}
+	if err := config.DB.Create(list).Error; err != nil {
+		t.Fatalf("create list: %v", err)
+	}
+	return list
+}
+
+func TestCardRepositoryCreatePersistsPosition(t *testing.T) {
+	openTestDB(t)
+	list := createTestList(t)
+	repo := NewCardRepository()
+
+	create := func(title string, position int) {
+		t.Helper()
+		card := &models.Card{Title: title, ListID: list.InternalID, ListPublicID: list.PublicID}
+		if err := repo.Create(card, position); err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+	}
+
+	// A, B, C ditambahkan di akhir, lalu X disisipkan di index 1 dan Y di awal.
+	create("A", -1)
+	create("B", -1)
+	create("C", -1)
+	create("X", 1)
+	create("Y", 0)
+
+	cards, err := repo.FindByListOrdered(list.InternalID)
+	if err != nil {
+		t.Fatalf("find cards: %v", err)
+	}
+
+	want := []string{"Y", "A", "X", "B", "C"}
+	if len(cards) != len(want) {
+		t.Fatalf("got %d cards, want %d", len(cards), len(want))
+	}
+	for i, card := range cards {
+		if card.Title != want[i] {
+			t.Errorf("cards[%d] = %s, want %s", i, card.Title, want[i])
+		}
+		if card.Position != i {
+			t.Errorf("cards[%d] (%s) position = %d, want %d", i, card.Title, card.Position, i)
+		}
+	}
+}
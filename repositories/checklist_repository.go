@@ -0,0 +1,340 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+)
+
+// ChecklistRepository adalah kontrak operasi database untuk tabel checklists dan checklist_items.
+//
+// Setiap operasi yang mengubah checklist/item me-lock baris kartu (FOR UPDATE) lebih dulu,
+// sehingga perubahan di kartu yang sama berjalan bergantian. Dengan begitu perhitungan rank
+// dan progress kartu (checklist_total & checklist_done) selalu memakai data terbaru.
+type ChecklistRepository interface {
+	Create(checklist *models.Checklist, position int) error
+	FindByPublicID(publicID uuid.UUID) (*models.Checklist, error)
+	FindByCard(cardID int64) ([]models.Checklist, error)
+	Update(checklist *models.Checklist) error
+	Move(checklist *models.Checklist, position int) error
+	Delete(checklist *models.Checklist) error
+
+	CreateItem(item *models.ChecklistItem, position int) error
+	FindItemByPublicID(publicID uuid.UUID) (*models.ChecklistItem, error)
+	UpdateItem(item *models.ChecklistItem) error
+	MoveItem(item *models.ChecklistItem, position int) error
+	DeleteItem(item *models.ChecklistItem) error
+}
+
+type checklistRepository struct{}
+
+// NewChecklistRepository membuat instance ChecklistRepository baru.
+func NewChecklistRepository() ChecklistRepository {
+	return &checklistRepository{}
+}
+
+// Create menyimpan checklist baru di index position. position < 0 berarti "paling bawah".
+func (r *checklistRepository) Create(checklist *models.Checklist, position int) error {
+	return withRankRetry(
+		func() error { return rebalanceChecklistRanks(checklist.CardID) },
+		func() error {
+			return config.DB.Transaction(func(tx *gorm.DB) error {
+				if err := lockRankParent(tx, &models.Card{}, checklist.CardID, lockUpdate); err != nil {
+					return err
+				}
+
+				rank, index, err := compactRankForPosition(func() *gorm.DB {
+					return tx.Model(&models.Checklist{}).Where("card_internal_id = ?", checklist.CardID)
+				}, position, func() error { return assignChecklistRanks(tx, checklist.CardID) })
+				if err != nil {
+					return err
+				}
+
+				checklist.Rank = rank
+				checklist.Position = index
+				checklist.Items = []models.ChecklistItem{}
+				return tx.Omit("Items").Create(checklist).Error
+			})
+		},
+	)
+}
+
+// FindByPublicID mencari checklist berdasarkan PublicID (tanpa item-nya).
+// Position diisi sesuai urutan rank.
+func (r *checklistRepository) FindByPublicID(publicID uuid.UUID) (*models.Checklist, error) {
+	var checklist models.Checklist
+	if err := config.DB.Where("public_id = ?", publicID).First(&checklist).Error; err != nil {
+		return &checklist, err
+	}
+
+	var before int64
+	err := config.DB.Model(&models.Checklist{}).
+		Where("card_internal_id = ?", checklist.CardID).
+		Where("(rank, internal_id) < (?, ?)", checklist.Rank, checklist.InternalID).
+		Count(&before).Error
+	checklist.Position = int(before)
+	return &checklist, err
+}
+
+// FindByCard mengambil semua checklist di kartu beserta item-nya, keduanya urut berdasarkan rank.
+func (r *checklistRepository) FindByCard(cardID int64) ([]models.Checklist, error) {
+	var checklists []models.Checklist
+	err := config.DB.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("rank ASC, internal_id ASC")
+		}).
+		Where("card_internal_id = ?", cardID).
+		Order("rank ASC, internal_id ASC").
+		Find(&checklists).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range checklists {
+		checklists[i].Position = i
+		for j := range checklists[i].Items {
+			checklists[i].Items[j].Position = j
+		}
+	}
+	return checklists, nil
+}
+
+// Update menyimpan perubahan judul checklist.
+func (r *checklistRepository) Update(checklist *models.Checklist) error {
+	checklist.UpdatedAt = time.Now()
+	return config.DB.Model(checklist).
+		Select("title", "updated_at").
+		Updates(checklist).Error
+}
+
+// Move memindahkan checklist ke index position di kartu yang sama.
+func (r *checklistRepository) Move(checklist *models.Checklist, position int) error {
+	return withRankRetry(
+		func() error { return rebalanceChecklistRanks(checklist.CardID) },
+		func() error {
+			return config.DB.Transaction(func(tx *gorm.DB) error {
+				if err := lockRankParent(tx, &models.Card{}, checklist.CardID, lockUpdate); err != nil {
+					return err
+				}
+
+				rank, index, err := compactRankForPosition(func() *gorm.DB {
+					return tx.Model(&models.Checklist{}).
+						Where("card_internal_id = ? AND internal_id <> ?", checklist.CardID, checklist.InternalID)
+				}, position, func() error { return assignChecklistRanks(tx, checklist.CardID) })
+				if err != nil {
+					return err
+				}
+
+				if err := tx.Model(&models.Checklist{InternalID: checklist.InternalID}).Updates(map[string]interface{}{
+					"rank":       rank,
+					"updated_at": time.Now(),
+				}).Error; err != nil {
+					return err
+				}
+
+				checklist.Rank = rank
+				checklist.Position = index
+				return nil
+			})
+		},
+	)
+}
+
+// Delete menghapus checklist (item-nya ikut terhapus lewat ON DELETE CASCADE)
+// lalu menghitung ulang progress kartu.
+func (r *checklistRepository) Delete(checklist *models.Checklist) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockRankParent(tx, &models.Card{}, checklist.CardID, lockUpdate); err != nil {
+			return err
+		}
+		if err := tx.Delete(checklist).Error; err != nil {
+			return err
+		}
+		return syncChecklistProgress(tx, checklist.CardID)
+	})
+}
+
+// CreateItem menyimpan item baru di index position. position < 0 berarti "paling bawah".
+func (r *checklistRepository) CreateItem(item *models.ChecklistItem, position int) error {
+	return withRankRetry(
+		func() error { return rebalanceChecklistItemRanks(item.CardID, item.ChecklistID) },
+		func() error {
+			return config.DB.Transaction(func(tx *gorm.DB) error {
+				if err := lockRankParent(tx, &models.Card{}, item.CardID, lockUpdate); err != nil {
+					return err
+				}
+
+				rank, index, err := compactRankForPosition(func() *gorm.DB {
+					return tx.Model(&models.ChecklistItem{}).Where("checklist_internal_id = ?", item.ChecklistID)
+				}, position, func() error { return assignChecklistItemRanks(tx, item.ChecklistID) })
+				if err != nil {
+					return err
+				}
+
+				item.Rank = rank
+				item.Position = index
+				if err := tx.Create(item).Error; err != nil {
+					return err
+				}
+				return syncChecklistProgress(tx, item.CardID)
+			})
+		},
+	)
+}
+
+// FindItemByPublicID mencari item berdasarkan PublicID. Position diisi sesuai urutan rank.
+func (r *checklistRepository) FindItemByPublicID(publicID uuid.UUID) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	if err := config.DB.Where("public_id = ?", publicID).First(&item).Error; err != nil {
+		return &item, err
+	}
+
+	var before int64
+	err := config.DB.Model(&models.ChecklistItem{}).
+		Where("checklist_internal_id = ?", item.ChecklistID).
+		Where("(rank, internal_id) < (?, ?)", item.Rank, item.InternalID).
+		Count(&before).Error
+	item.Position = int(before)
+	return &item, err
+}
+
+// UpdateItem menyimpan perubahan isi item lalu menghitung ulang progress kartu.
+func (r *checklistRepository) UpdateItem(item *models.ChecklistItem) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockRankParent(tx, &models.Card{}, item.CardID, lockUpdate); err != nil {
+			return err
+		}
+
+		item.UpdatedAt = time.Now()
+		if err := tx.Model(item).
+			Select("title", "checked", "checked_at", "assignee_internal_id", "assignee_public_id", "due_date", "updated_at").
+			Updates(item).Error; err != nil {
+			return err
+		}
+		return syncChecklistProgress(tx, item.CardID)
+	})
+}
+
+// MoveItem memindahkan item ke index position di checklist yang sama.
+func (r *checklistRepository) MoveItem(item *models.ChecklistItem, position int) error {
+	return withRankRetry(
+		func() error { return rebalanceChecklistItemRanks(item.CardID, item.ChecklistID) },
+		func() error {
+			return config.DB.Transaction(func(tx *gorm.DB) error {
+				if err := lockRankParent(tx, &models.Card{}, item.CardID, lockUpdate); err != nil {
+					return err
+				}
+
+				rank, index, err := compactRankForPosition(func() *gorm.DB {
+					return tx.Model(&models.ChecklistItem{}).
+						Where("checklist_internal_id = ? AND internal_id <> ?", item.ChecklistID, item.InternalID)
+				}, position, func() error { return assignChecklistItemRanks(tx, item.ChecklistID) })
+				if err != nil {
+					return err
+				}
+
+				if err := tx.Model(&models.ChecklistItem{InternalID: item.InternalID}).Updates(map[string]interface{}{
+					"rank":       rank,
+					"updated_at": time.Now(),
+				}).Error; err != nil {
+					return err
+				}
+
+				item.Rank = rank
+				item.Position = index
+				return nil
+			})
+		},
+	)
+}
+
+// DeleteItem menghapus item lalu menghitung ulang progress kartu.
+func (r *checklistRepository) DeleteItem(item *models.ChecklistItem) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockRankParent(tx, &models.Card{}, item.CardID, lockUpdate); err != nil {
+			return err
+		}
+		if err := tx.Delete(item).Error; err != nil {
+			return err
+		}
+		return syncChecklistProgress(tx, item.CardID)
+	})
+}
+
+// syncChecklistProgress menghitung ulang cards.checklist_total & checklist_done dari checklist_items.
+// Dihitung ulang (bukan ditambah/dikurangi) agar nilainya tidak pernah melenceng.
+func syncChecklistProgress(tx *gorm.DB, cardID int64) error {
+	return tx.Exec(`
+		UPDATE cards SET
+			checklist_total = (SELECT count(*) FROM checklist_items WHERE card_internal_id = cards.internal_id),
+			checklist_done = (SELECT count(*) FROM checklist_items WHERE card_internal_id = cards.internal_id AND checked)
+		WHERE internal_id = ?`, cardID).Error
+}
+
+// checklistRankMaxLength adalah panjang rank checklist/item maksimal sebelum dirapikan
+// (sama dengan batas rank list & kartu di RankRebalancer). Checklist tidak ikut dirapikan
+// RankRebalancer, jadi perapian dilakukan langsung saat rank baru melewati batas ini.
+const checklistRankMaxLength = 16
+
+// compactRankForPosition sama dengan rankForPosition, tetapi jika rank yang dihasilkan lebih
+// panjang dari checklistRankMaxLength (misal karena terus disisipi di paling atas), rank
+// seluruh grup diberi ulang lewat rebalance lalu rank dihitung sekali lagi.
+func compactRankForPosition(scope func() *gorm.DB, position int, rebalance func() error) (string, int, error) {
+	rank, index, err := rankForPosition(scope, position)
+	if err != nil || len(rank) <= checklistRankMaxLength {
+		return rank, index, err
+	}
+	if err := rebalance(); err != nil {
+		return "", 0, err
+	}
+	return rankForPosition(scope, position)
+}
+
+// rebalanceChecklistRanks memberi ulang rank yang jaraknya merata untuk semua checklist di kartu.
+func rebalanceChecklistRanks(cardID int64) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockRankParent(tx, &models.Card{}, cardID, lockUpdate); err != nil {
+			return err
+		}
+		return assignChecklistRanks(tx, cardID)
+	})
+}
+
+// rebalanceChecklistItemRanks memberi ulang rank yang jaraknya merata untuk semua item di checklist.
+func rebalanceChecklistItemRanks(cardID, checklistID int64) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockRankParent(tx, &models.Card{}, cardID, lockUpdate); err != nil {
+			return err
+		}
+		return assignChecklistItemRanks(tx, checklistID)
+	})
+}
+
+// assignChecklistRanks memberi rank baru ke checklist di kartu dengan urutan yang sama.
+// Pemanggil harus sudah me-lock baris kartu.
+func assignChecklistRanks(tx *gorm.DB, cardID int64) error {
+	var ids []int64
+	if err := tx.Model(&models.Checklist{}).
+		Where("card_internal_id = ?", cardID).
+		Order("rank ASC, internal_id ASC").
+		Pluck("internal_id", &ids).Error; err != nil {
+		return err
+	}
+	return assignRanks(tx, &models.Checklist{}, ids)
+}
+
+// assignChecklistItemRanks memberi rank baru ke item di checklist dengan urutan yang sama.
+// Pemanggil harus sudah me-lock baris kartu.
+func assignChecklistItemRanks(tx *gorm.DB, checklistID int64) error {
+	var ids []int64
+	if err := tx.Model(&models.ChecklistItem{}).
+		Where("checklist_internal_id = ?", checklistID).
+		Order("rank ASC, internal_id ASC").
+		Pluck("internal_id", &ids).Error; err != nil {
+		return err
+	}
+	return assignRanks(tx, &models.ChecklistItem{}, ids)
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+)
+
+func TestChecklistRepositoryHeadInsertsKeepRanksShort(t *testing.T) {
+	openTestDB(t)
+	list := createTestList(t)
+
+	card := &models.Card{Title: "Card", ListID: list.InternalID, ListPublicID: list.PublicID}
+	if err := config.DB.Create(card).Error; err != nil {
+		t.Fatalf("create card: %v", err)
+	}
+
+	repo := NewChecklistRepository()
+	const count = 100
+	for i := 0; i < count; i++ {
+		checklist := &models.Checklist{Title: fmt.Sprintf("C%03d", i), CardID: card.InternalId, CardPublicID: card.PublicId}
+		if err := repo.Create(checklist, 0); err != nil {
+			t.Fatalf("create checklist #%d: %v", i, err)
+		}
+	}
+
+	checklists, err := repo.FindByCard(card.InternalId)
+	if err != nil {
+		t.Fatalf("find checklists: %v", err)
+	}
+	if len(checklists) != count {
+		t.Fatalf("got %d checklists, want %d", len(checklists), count)
+	}
+	for i, checklist := range checklists {
+		// Setiap checklist disisipkan paling atas, jadi urutannya terbalik.
+		if want := fmt.Sprintf("C%03d", count-1-i); checklist.Title != want {
+			t.Errorf("checklists[%d] = %s, want %s", i, checklist.Title, want)
+		}
+		if len(checklist.Rank) > checklistRankMaxLength {
+			t.Errorf("checklists[%d] rank %q is longer than %d", i, checklist.Rank, checklistRankMaxLength)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+)
+
+// CommentRepository adalah kontrak operasi database untuk tabel comments
+// beserta riwayat edit (comment_revisions) dan mention (comment_mentions).
+type CommentRepository interface {
+	Create(comment *models.Comment, mentions []models.CommentMention) error
+	FindByPublicID(publicID uuid.UUID) (*models.Comment, error)
+	FindByCard(cardID int64) ([]models.Comment, error)
+	Update(comment *models.Comment, revision *models.CommentRevision, mentions []models.CommentMention) error
+	Delete(comment *models.Comment) error
+	FindRevisions(commentID int64) ([]models.CommentRevision, error)
+}
+
+type commentRepository struct{}
+
+// NewCommentRepository membuat instance CommentRepository baru.
+func NewCommentRepository() CommentRepository {
+	return &commentRepository{}
+}
+
+// Create menyimpan komentar beserta mention-nya dalam satu transaksi.
+func (r *commentRepository) Create(comment *models.Comment, mentions []models.CommentMention) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Mentions").Create(comment).Error; err != nil {
+			return err
+		}
+		return replaceMentions(tx, comment, mentions)
+	})
+}
+
+// FindByPublicID mencari komentar (yang belum dihapus) berdasarkan PublicID, beserta mention-nya.
+func (r *commentRepository) FindByPublicID(publicID uuid.UUID) (*models.Comment, error) {
+	var comment models.Comment
+	err := config.DB.Preload("Mentions").Where("public_id = ?", publicID).First(&comment).Error
+	return &comment, err
+}
+
+// FindByCard mengambil semua komentar di kartu, dari yang paling lama.
+// Balasan dikembalikan di daftar yang sama; client menyusun thread lewat parent_id.
+//
+// Komentar utama yang sudah dihapus tetap dikembalikan sebagai tombstone (lihat
+// models.Comment.Tombstone) selama masih punya balasan yang belum dihapus, supaya
+// parent_id balasan-balasan itu selalu menunjuk ke komentar yang ada di daftar.
+func (r *commentRepository) FindByCard(cardID int64) ([]models.Comment, error) {
+	var comments []models.Comment
+	err := config.DB.Unscoped().Preload("Mentions").
+		Where("card_internal_id = ?", cardID).
+		Where(`(comments.deleted_at IS NULL OR EXISTS (
+			SELECT 1 FROM comments replies
+			WHERE replies.parent_internal_id = comments.internal_id AND replies.deleted_at IS NULL))`).
+		Order("created_at ASC, internal_id ASC").
+		Find(&comments).Error
+
+	for i := range comments {
+		if comments[i].DeletedAt.Valid {
+			comments[i].Tombstone()
+		}
+	}
+	return comments, err
+}
+
+// Update menyimpan isi baru komentar, mencatat isi lama ke comment_revisions,
+// dan mengganti daftar mention, semuanya dalam satu transaksi.
+func (r *commentRepository) Update(comment *models.Comment, revision *models.CommentRevision, mentions []models.CommentMention) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(comment).
+			Select("message", "edited_at", "updated_at").
+			Updates(comment).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_internal_id = ?", comment.InternalID).
+			Delete(&models.CommentMention{}).Error; err != nil {
+			return err
+		}
+		return replaceMentions(tx, comment, mentions)
+	})
+}
+
+// Delete melakukan soft delete (mengisi deleted_at).
+func (r *commentRepository) Delete(comment *models.Comment) error {
+	return config.DB.Delete(comment).Error
+}
+
+// FindRevisions mengambil riwayat edit komentar, dari yang paling baru.
+func (r *commentRepository) FindRevisions(commentID int64) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	err := config.DB.Where("comment_internal_id = ?", commentID).
+		Order("created_at DESC, internal_id DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// replaceMentions menyimpan mentions untuk comment dan mengisinya ke comment.Mentions.
+func replaceMentions(tx *gorm.DB, comment *models.Comment, mentions []models.CommentMention) error {
+	for i := range mentions {
+		mentions[i].CommentID = comment.InternalID
+	}
+	if len(mentions) > 0 {
+		if err := tx.Create(&mentions).Error; err != nil {
+			return err
+		}
+	}
+	comment.Mentions = mentions
+	return nil
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/databases"
+)
+
+// HealthRepository adalah kontrak pengecekan kesehatan database untuk readiness probe.
+type HealthRepository interface {
+	// Ping mengecek database bisa dijangkau.
+	Ping(ctx context.Context) error
+
+	// MigrationStatus membaca versi skema saat ini tanpa advisory lock (lihat databases.Migrator.Peek).
+	MigrationStatus(ctx context.Context) (*databases.MigrationStatus, error)
+}
+
+// healthRepository adalah implementasi HealthRepository menggunakan pool config.DB.
+type healthRepository struct{}
+
+// NewHealthRepository membuat instance HealthRepository baru.
+func NewHealthRepository() HealthRepository {
+	return &healthRepository{}
+}
+
+// Ping mengirim ping ke primary database.
+func (r *healthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// MigrationStatus membandingkan versi di schema_migrations dengan migration yang di-embed.
+func (r *healthRepository) MigrationStatus(ctx context.Context) (*databases.MigrationStatus, error) {
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := databases.NewMigrator(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+	return migrator.Peek(ctx)
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm/clause"
+)
+
+// LabelRepository adalah kontrak operasi database untuk tabel labels dan card_labels.
+type LabelRepository interface {
+	Create(label *models.Label) error
+	FindByPublicID(publicID uuid.UUID) (*models.Label, error)
+	FindByPublicIDs(boardID int64, publicIDs []uuid.UUID) ([]models.Label, error)
+	FindByBoard(boardID int64) ([]models.Label, error)
+	FindByCard(cardID int64) ([]models.Label, error)
+	Update(label *models.Label) error
+	Delete(label *models.Label) error
+	Attach(cardID, labelID int64) error
+	Detach(cardID, labelID int64) error
+}
+
+type labelRepository struct{}
+
+// NewLabelRepository membuat instance LabelRepository baru.
+func NewLabelRepository() LabelRepository {
+	return &labelRepository{}
+}
+
+// Create menyimpan label baru.
+func (r *labelRepository) Create(label *models.Label) error {
+	return config.DB.Create(label).Error
+}
+
+// FindByPublicID mencari label berdasarkan PublicID.
+func (r *labelRepository) FindByPublicID(publicID uuid.UUID) (*models.Label, error) {
+	var label models.Label
+	err := config.DB.Where("public_id = ?", publicID).First(&label).Error
+	return &label, err
+}
+
+// FindByPublicIDs mengambil label-label milik board dari daftar PublicID.
+// Label dari board lain tidak ikut dikembalikan.
+func (r *labelRepository) FindByPublicIDs(boardID int64, publicIDs []uuid.UUID) ([]models.Label, error) {
+	var labels []models.Label
+	err := config.DB.Where("board_internal_id = ? AND public_id IN ?", boardID, publicIDs).Find(&labels).Error
+	return labels, err
+}
+
+// FindByBoard mengambil semua label milik board.
+func (r *labelRepository) FindByBoard(boardID int64) ([]models.Label, error) {
+	var labels []models.Label
+	err := config.DB.Where("board_internal_id = ?", boardID).Order("created_at ASC").Find(&labels).Error
+	return labels, err
+}
+
+// FindByCard mengambil semua label yang ditempel di kartu.
+func (r *labelRepository) FindByCard(cardID int64) ([]models.Label, error) {
+	var labels []models.Label
+	err := config.DB.
+		Joins("JOIN card_labels cl ON cl.label_internal_id = labels.internal_id").
+		Where("cl.card_internal_id = ?", cardID).
+		Order("labels.created_at ASC").
+		Find(&labels).Error
+	return labels, err
+}
+
+// Update menyimpan nama dan warna label.
+func (r *labelRepository) Update(label *models.Label) error {
+	return config.DB.Model(label).Select("name", "color").Updates(label).Error
+}
+
+// Delete menghapus label. Baris card_labels ikut terhapus lewat ON DELETE CASCADE.
+func (r *labelRepository) Delete(label *models.Label) error {
+	return config.DB.Delete(label).Error
+}
+
+// Attach menempelkan label ke kartu. Jika sudah tertempel, tidak terjadi apa-apa (idempotent).
+func (r *labelRepository) Attach(cardID, labelID int64) error {
+	return config.DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.CardLabel{CardID: cardID, LabelID: labelID}).Error
+}
+
+// Detach melepas label dari kartu.
+func (r *labelRepository) Detach(cardID, labelID int64) error {
+	return config.DB.
+		Where("card_internal_id = ? AND label_internal_id = ?", cardID, labelID).
+		Delete(&models.CardLabel{}).Error
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListRepository adalah kontrak operasi database untuk tabel lists.
+//
+// Urutan list di sebuah board disimpan di models.ListPosition.ListOrder.
+// Setiap operasi yang mengubah list (create, delete, move) WAJIB ikut
+// memperbarui ListOrder di transaksi yang sama agar keduanya selalu konsisten.
+type ListRepository interface {
+	Create(list *models.List, position int) error
+	FindByPublicID(publicID uuid.UUID) (*models.List, error)
+	FindByID(id int64) (*models.List, error)
+	FindByBoardOrdered(boardID int64) ([]models.List, error)
+	UpdateTitle(list *models.List) error
+	Delete(list *models.List) error
+	Move(list *models.List, position int) error
+}
+
+type listRepository struct{}
+
+// NewListRepository membuat instance ListRepository baru.
+func NewListRepository() ListRepository {
+	return &listRepository{}
+}
+
+// Create menyimpan list baru, menyisipkannya ke ListOrder di index position,
+// dan membuat baris models.CardPosition kosong untuk list tersebut.
+// position < 0 berarti "paling akhir".
+func (r *listRepository) Create(list *models.List, position int) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		listPosition, err := lockListPosition(tx, list.BoardInternalID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Create(list).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.CardPosition{ListID: list.InternalID}).Error; err != nil {
+			return err
+		}
+
+		if position < 0 {
+			position = len(listPosition.ListOrder)
+		}
+		listPosition.ListOrder = listPosition.ListOrder.Insert(position, list.PublicID)
+		return saveListOrder(tx, listPosition)
+	})
+}
+
+// FindByPublicID mencari list berdasarkan PublicID.
+func (r *listRepository) FindByPublicID(publicID uuid.UUID) (*models.List, error) {
+	var list models.List
+	err := config.DB.Where("public_id = ?", publicID).First(&list).Error
+	return &list, err
+}
+
+// FindByID mencari list berdasarkan InternalID.
+func (r *listRepository) FindByID(id int64) (*models.List, error) {
+	var list models.List
+	err := config.DB.First(&list, id).Error
+	return &list, err
+}
+
+// FindByBoardOrdered mengambil semua list di board sesuai urutan di ListOrder.
+//
+// List yang (karena alasan apapun) tidak tercatat di ListOrder tetap dikembalikan
+// di bagian akhir, diurutkan berdasarkan waktu dibuat, agar tidak "hilang" dari tampilan.
+func (r *listRepository) FindByBoardOrdered(boardID int64) ([]models.List, error) {
+	var lists []models.List
+	if err := config.DB.Where("board_internal_id = ?", boardID).Order("created_at ASC").Find(&lists).Error; err != nil {
+		return nil, err
+	}
+
+	var listPosition models.ListPosition
+	err := config.DB.Where("board_id = ?", boardID).First(&listPosition).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]models.List, len(lists))
+	for _, list := range lists {
+		byID[list.PublicID] = list
+	}
+
+	ordered := make([]models.List, 0, len(lists))
+	for _, id := range listPosition.ListOrder {
+		if list, ok := byID[id]; ok {
+			ordered = append(ordered, list)
+			delete(byID, id)
+		}
+	}
+	for _, list := range lists {
+		if _, remaining := byID[list.PublicID]; remaining {
+			ordered = append(ordered, list)
+		}
+	}
+	return ordered, nil
+}
+
+// UpdateTitle menyimpan judul baru list.
+func (r *listRepository) UpdateTitle(list *models.List) error {
+	list.UpdatedAt = time.Now()
+	return config.DB.Model(list).Select("title", "updated_at").Updates(list).Error
+}
+
+// Delete menghapus list sekaligus mengeluarkan ID-nya dari ListOrder.
+func (r *listRepository) Delete(list *models.List) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		listPosition, err := lockListPosition(tx, list.BoardInternalID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(list).Error; err != nil {
+			return err
+		}
+
+		listPosition.ListOrder = listPosition.ListOrder.Remove(list.PublicID)
+		return saveListOrder(tx, listPosition)
+	})
+}
+
+// Move memindahkan list ke index position di dalam board yang sama.
+func (r *listRepository) Move(list *models.List, position int) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		listPosition, err := lockListPosition(tx, list.BoardInternalID)
+		if err != nil {
+			return err
+		}
+
+		order := listPosition.ListOrder.Remove(list.PublicID)
+		listPosition.ListOrder = order.Insert(position, list.PublicID)
+		return saveListOrder(tx, listPosition)
+	})
+}
+
+// lockListPosition mengambil baris ListPosition milik board dengan "SELECT ... FOR UPDATE".
+//
+// Kenapa perlu lock? Dua user yang menggeser list bersamaan akan membaca ListOrder yang sama,
+// lalu saling menimpa hasilnya. Dengan row lock, transaksi kedua menunggu transaksi pertama
+// selesai lalu membaca ListOrder terbaru.
+//
+// Jika barisnya belum ada (board lama), baris kosong dibuat dulu.
+func lockListPosition(tx *gorm.DB, boardID int64) (*models.ListPosition, error) {
+	var listPosition models.ListPosition
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("board_id = ?", boardID).
+		First(&listPosition).Error
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &listPosition, err
+	}
+
+	// ON CONFLICT DO NOTHING: jika transaksi lain membuat baris yang sama di saat bersamaan,
+	// insert kita diabaikan lalu baris milik transaksi lain yang di-lock.
+	empty := models.ListPosition{BoardID: boardID}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&empty).Error; err != nil {
+		return nil, err
+	}
+
+	listPosition = models.ListPosition{}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("board_id = ?", boardID).
+		First(&listPosition).Error
+	return &listPosition, err
+}
+
+// saveListOrder menyimpan kolom list_order saja.
+func saveListOrder(tx *gorm.DB, listPosition *models.ListPosition) error {
+	return tx.Model(listPosition).Update("list_order", listPosition.ListOrder).Error
+}
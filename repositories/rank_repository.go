@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Kekuatan row lock untuk baris "induk" (board untuk list, list untuk kartu).
+// Operasi create/move memakai SHARE (boleh berjalan bersamaan), rebalance memakai
+// UPDATE (menunggu semua create/move selesai dan menahan yang baru).
+const (
+	lockShare  = "SHARE"
+	lockUpdate = "UPDATE"
+)
+
+// RankRepository berisi operasi perawatan kolom rank untuk rank rebalancer.
+//
+// Rank "rusak" jika:
+//   - kosong: baris dibuat saat ORDERING_STRATEGY=array (atau sebelum migration rank),
+//   - terlalu panjang: terlalu sering disisipi di titik yang sama,
+//   - kembar: dua request menyisipkan di celah yang sama secara bersamaan.
+type RankRepository interface {
+	FindBoardsNeedingRebalance(maxLength, limit int) ([]int64, error)
+	FindListsNeedingRebalance(maxLength, limit int) ([]int64, error)
+	RebalanceBoard(boardID int64) error
+	RebalanceList(listID int64) error
+}
+
+type rankRepository struct{}
+
+// NewRankRepository membuat instance RankRepository baru.
+func NewRankRepository() RankRepository {
+	return &rankRepository{}
+}
+
+// needsRebalanceHaving adalah kondisi HAVING untuk mencari grup dengan rank yang rusak.
+const needsRebalanceHaving = "bool_or(rank = '') OR max(length(rank)) > ? OR count(*) > count(DISTINCT rank)"
+
+// FindBoardsNeedingRebalance mengembalikan board yang rank list-nya perlu dirapikan.
+func (r *rankRepository) FindBoardsNeedingRebalance(maxLength, limit int) ([]int64, error) {
+	var boardIDs []int64
+	err := config.DB.Model(&models.List{}).
+		Group("board_internal_id").
+		Having(needsRebalanceHaving, maxLength).
+		Limit(limit).
+		Pluck("board_internal_id", &boardIDs).Error
+	return boardIDs, err
+}
+
+// FindListsNeedingRebalance mengembalikan list yang rank kartunya perlu dirapikan.
+func (r *rankRepository) FindListsNeedingRebalance(maxLength, limit int) ([]int64, error) {
+	var listIDs []int64
+	err := config.DB.Model(&models.Card{}).
+		Group("list_id").
+		Having(needsRebalanceHaving, maxLength).
+		Limit(limit).
+		Pluck("list_id", &listIDs).Error
+	return listIDs, err
+}
+
+// RebalanceBoard memberi ulang rank yang jaraknya merata untuk semua list di board.
+func (r *rankRepository) RebalanceBoard(boardID int64) error {
+	return rebalanceListRanks(boardID)
+}
+
+// RebalanceList memberi ulang rank yang jaraknya merata untuk semua kartu di list.
+func (r *rankRepository) RebalanceList(listID int64) error {
+	return rebalanceCardRanks(listID)
+}
+
+// rebalanceListRanks merapikan rank list di board.
+//
+// Jika ada list yang rank-nya masih kosong, urutan diambil dari ListPosition.ListOrder
+// (inilah jalur migrasi dari strategi "array"). Jika tidak, urutan rank yang sekarang dipertahankan.
+func rebalanceListRanks(boardID int64) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockRankParent(tx, &models.Board{}, boardID, lockUpdate); err != nil {
+			return err
+		}
+
+		var backfill bool
+		if err := tx.Model(&models.List{}).
+			Select("count(*) > 0").
+			Where("board_internal_id = ? AND rank = ''", boardID).
+			Scan(&backfill).Error; err != nil {
+			return err
+		}
+
+		query := tx.Table("lists").Select("lists.internal_id").Where("lists.board_internal_id = ?", boardID)
+		if backfill {
+			query = query.
+				Joins("LEFT JOIN list_positions lp ON lp.board_id = lists.board_internal_id").
+				Order("array_position(lp.list_order, lists.public_id) ASC NULLS LAST, lists.created_at ASC, lists.internal_id ASC")
+		} else {
+			query = query.Order("lists.rank ASC, lists.internal_id ASC")
+		}
+
+		var ids []int64
+		if err := query.Pluck("lists.internal_id", &ids).Error; err != nil {
+			return err
+		}
+		return assignRanks(tx, &models.List{}, ids)
+	})
+}
+
+// rebalanceCardRanks merapikan rank kartu di list, dengan aturan yang sama seperti rebalanceListRanks
+// (urutan awal diambil dari CardPosition.CardOrder).
+func rebalanceCardRanks(listID int64) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockRankParent(tx, &models.List{}, listID, lockUpdate); err != nil {
+			return err
+		}
+
+		var backfill bool
+		if err := tx.Model(&models.Card{}).
+			Select("count(*) > 0").
+			Where("list_id = ? AND rank = ''", listID).
+			Scan(&backfill).Error; err != nil {
+			return err
+		}
+
+		query := tx.Table("cards").Select("cards.internal_id").Where("cards.list_id = ?", listID)
+		if backfill {
+			query = query.
+				Joins("LEFT JOIN card_positions cp ON cp.list_internal_id = cards.list_id").
+				Order("array_position(cp.card_order, cards.public_id) ASC NULLS LAST, cards.created_at ASC, cards.internal_id ASC")
+		} else {
+			query = query.Order("cards.rank ASC, cards.internal_id ASC")
+		}
+
+		var ids []int64
+		if err := query.Pluck("cards.internal_id", &ids).Error; err != nil {
+			return err
+		}
+		return assignRanks(tx, &models.Card{}, ids)
+	})
+}
+
+// assignRanks memberi rank berjarak merata (utils.RankSequence) sesuai urutan ids.
+func assignRanks(tx *gorm.DB, model interface{}, ids []int64) error {
+	ranks := utils.RankSequence(len(ids))
+	for i, id := range ids {
+		if err := tx.Model(model).Where("internal_id = ?", id).Update("rank", ranks[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockRankParent me-lock baris induk (board atau list) dengan kekuatan strength.
+// Mengembalikan gorm.ErrRecordNotFound jika induknya sudah dihapus.
+func lockRankParent(tx *gorm.DB, model interface{}, id int64, strength string) error {
+	return tx.Clauses(clause.Locking{Strength: strength}).
+		Select("internal_id").
+		Where("internal_id = ?", id).
+		Take(model).Error
+}
+
+// rankForPosition menghitung rank untuk item yang akan ditaruh di index position.
+//
+// scope mengembalikan query item-item lain di grup yang sama (tanpa item yang dipindah).
+// Yang dibaca hanya dua tetangga (index position-1 dan position), bukan seluruh isi grup.
+// Nilai kembalian kedua adalah index sebenarnya (position dibatasi sampai jumlah item).
+func rankForPosition(scope func() *gorm.DB, position int) (string, int, error) {
+	var prev, next string
+
+	if position == 0 {
+		var ranks []string
+		if err := scope().Order("rank ASC, internal_id ASC").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+			return "", 0, err
+		}
+		if len(ranks) > 0 {
+			next = ranks[0]
+		}
+	} else {
+		var ranks []string
+		if position > 0 {
+			if err := scope().Order("rank ASC, internal_id ASC").
+				Offset(position-1).Limit(2).
+				Pluck("rank", &ranks).Error; err != nil {
+				return "", 0, err
+			}
+		}
+
+		switch len(ranks) {
+		case 2:
+			prev, next = ranks[0], ranks[1]
+		case 1:
+			prev = ranks[0]
+		default:
+			// position < 0 atau melewati item terakhir: taruh paling akhir.
+			var count int64
+			if err := scope().Count(&count).Error; err != nil {
+				return "", 0, err
+			}
+			if err := scope().Order("rank DESC, internal_id DESC").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+				return "", 0, err
+			}
+			if len(ranks) > 0 {
+				prev = ranks[0]
+			}
+			position = int(count)
+		}
+	}
+
+	rank, err := utils.RankBetween(prev, next)
+	return rank, position, err
+}
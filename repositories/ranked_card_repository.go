@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+)
+
+// rankedCardRepository adalah CardRepository untuk ORDERING_STRATEGY=rank.
+//
+// Urutan kartu ditentukan kolom cards.rank. Memindahkan kartu (termasuk ke list lain)
+// cukup meng-update SATU baris kartu: list_id dan rank-nya. Tidak ada array yang
+// perlu ditulis ulang, sehingga drag & drop di list yang ramai tidak saling menunggu.
+type rankedCardRepository struct {
+	cardRepository
+}
+
+// NewRankedCardRepository membuat CardRepository yang memakai kolom rank untuk urutan.
+func NewRankedCardRepository() CardRepository {
+	return &rankedCardRepository{}
+}
+
+// Create menyimpan kartu baru dengan rank sesuai index position.
+// position < 0 berarti "paling bawah".
+func (r *rankedCardRepository) Create(card *models.Card, position int) error {
+	return withRankRetry(
+		func() error { return rebalanceCardRanks(card.ListID) },
+		func() error {
+			return config.DB.Transaction(func(tx *gorm.DB) error {
+				if err := lockRankParent(tx, &models.List{}, card.ListID, lockShare); err != nil {
+					return err
+				}
+
+				rank, index, err := rankForPosition(func() *gorm.DB {
+					return tx.Model(&models.Card{}).Where("list_id = ?", card.ListID)
+				}, position)
+				if err != nil {
+					return err
+				}
+
+				card.Rank = rank
+				card.Position = index
+				return tx.Create(card).Error
+			})
+		},
+	)
+}
+
+// FindByListOrdered mengambil semua kartu di list diurutkan berdasarkan rank.
+// Position diisi dengan index hasil urutan tersebut.
+func (r *rankedCardRepository) FindByListOrdered(listID int64) ([]models.Card, error) {
+	var cards []models.Card
+	if err := config.DB.Where("list_id = ?", listID).
+		Order("rank ASC, internal_id ASC").
+		Find(&cards).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range cards {
+		cards[i].Position = i
+	}
+	return cards, nil
+}
+
+// Delete menghapus kartu. Tidak ada urutan lain yang perlu diperbarui.
+func (r *rankedCardRepository) Delete(card *models.Card) error {
+	return config.DB.Delete(card).Error
+}
+
+// Move memindahkan kartu ke list target di index position dengan meng-update satu baris kartu.
+func (r *rankedCardRepository) Move(card *models.Card, target *models.List, position int) error {
+	return withRankRetry(
+		func() error { return rebalanceCardRanks(target.InternalID) },
+		func() error {
+			return config.DB.Transaction(func(tx *gorm.DB) error {
+				// Lock SHARE di list asal & tujuan hanya mencegah rebalancer mengubah rank
+				// di tengah perhitungan. Lock SHARE tidak saling menunggu, jadi banyak
+				// pemindahan kartu bisa berjalan bersamaan.
+				listIDs := []int64{card.ListID, target.InternalID}
+				sort.Slice(listIDs, func(i, j int) bool { return listIDs[i] < listIDs[j] })
+				for _, listID := range listIDs {
+					if err := lockRankParent(tx, &models.List{}, listID, lockShare); err != nil {
+						return err
+					}
+				}
+
+				rank, index, err := rankForPosition(func() *gorm.DB {
+					return tx.Model(&models.Card{}).
+						Where("list_id = ? AND internal_id <> ?", target.InternalID, card.InternalId)
+				}, position)
+				if err != nil {
+					return err
+				}
+
+				if err := tx.Model(&models.Card{InternalId: card.InternalId}).Updates(map[string]interface{}{
+					"list_id":        target.InternalID,
+					"list_public_id": target.PublicID,
+					"rank":           rank,
+					"updated_at":     time.Now(),
+				}).Error; err != nil {
+					return err
+				}
+
+				card.ListID = target.InternalID
+				card.ListPublicID = target.PublicID
+				card.Rank = rank
+				card.Position = index
+				return nil
+			})
+		},
+	)
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// rankedListRepository adalah ListRepository untuk ORDERING_STRATEGY=rank.
+//
+// Urutan list ditentukan kolom lists.rank, bukan ListPosition.ListOrder. Membuat,
+// memindahkan, atau menghapus list hanya menyentuh baris list itu sendiri, sehingga
+// dua user yang menggeser list berbeda di board yang sama tidak saling menunggu.
+//
+// Operasi yang tidak berhubungan dengan urutan (FindByPublicID, UpdateTitle, dll)
+// diwarisi dari listRepository.
+type rankedListRepository struct {
+	listRepository
+}
+
+// NewRankedListRepository membuat ListRepository yang memakai kolom rank untuk urutan.
+func NewRankedListRepository() ListRepository {
+	return &rankedListRepository{}
+}
+
+// Create menyimpan list baru dengan rank di antara list ke-(position-1) dan ke-position.
+// position < 0 berarti "paling akhir".
+func (r *rankedListRepository) Create(list *models.List, position int) error {
+	return withRankRetry(
+		func() error { return rebalanceListRanks(list.BoardInternalID) },
+		func() error {
+			return config.DB.Transaction(func(tx *gorm.DB) error {
+				if err := lockRankParent(tx, &models.Board{}, list.BoardInternalID, lockShare); err != nil {
+					return err
+				}
+
+				rank, _, err := rankForPosition(func() *gorm.DB {
+					return tx.Model(&models.List{}).Where("board_internal_id = ?", list.BoardInternalID)
+				}, position)
+				if err != nil {
+					return err
+				}
+
+				list.Rank = rank
+				return tx.Create(list).Error
+			})
+		},
+	)
+}
+
+// FindByBoardOrdered mengambil semua list di board diurutkan berdasarkan rank.
+// internal_id dipakai sebagai pemecah seri jika (jarang terjadi) ada dua rank yang sama.
+func (r *rankedListRepository) FindByBoardOrdered(boardID int64) ([]models.List, error) {
+	var lists []models.List
+	err := config.DB.Where("board_internal_id = ?", boardID).
+		Order("rank ASC, internal_id ASC").
+		Find(&lists).Error
+	return lists, err
+}
+
+// Delete menghapus list. Tidak ada urutan lain yang perlu diperbarui.
+func (r *rankedListRepository) Delete(list *models.List) error {
+	return config.DB.Delete(list).Error
+}
+
+// Move memindahkan list ke index position dengan mengganti rank list itu saja.
+func (r *rankedListRepository) Move(list *models.List, position int) error {
+	return withRankRetry(
+		func() error { return rebalanceListRanks(list.BoardInternalID) },
+		func() error {
+			return config.DB.Transaction(func(tx *gorm.DB) error {
+				if err := lockRankParent(tx, &models.Board{}, list.BoardInternalID, lockShare); err != nil {
+					return err
+				}
+
+				rank, _, err := rankForPosition(func() *gorm.DB {
+					return tx.Model(&models.List{}).
+						Where("board_internal_id = ? AND internal_id <> ?", list.BoardInternalID, list.InternalID)
+				}, position)
+				if err != nil {
+					return err
+				}
+
+				list.Rank = rank
+				return tx.Model(list).Update("rank", rank).Error
+			})
+		},
+	)
+}
+
+// withRankRetry menjalankan op, dan jika gagal karena tidak ada ruang rank
+// (dua tetangga punya rank yang sama), merapikan rank dulu lalu mencoba sekali lagi.
+func withRankRetry(rebalance func() error, op func() error) error {
+	err := op()
+	if !errors.Is(err, utils.ErrInvalidRank) {
+		return err
+	}
+	if err := rebalance(); err != nil {
+		return err
+	}
+	return op()
+}
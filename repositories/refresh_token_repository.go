@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm"
+)
+
+// ErrRefreshTokenAlreadyUsed dikembalikan Rotate jika token lama ternyata sudah
+// direvoke oleh request lain (misal dua request refresh bersamaan dengan token yang sama).
+var ErrRefreshTokenAlreadyUsed = errors.New("refresh token already used")
+
+// RefreshTokenRepository adalah kontrak operasi database untuk tabel refresh_tokens.
+type RefreshTokenRepository interface {
+	Create(token *models.RefreshToken) error
+	FindByHash(hash string) (*models.RefreshToken, error)
+	Rotate(current *models.RefreshToken, next *models.RefreshToken) error
+	RevokeFamily(familyID uuid.UUID) error
+	RevokeAllForUser(userID int64) error
+}
+
+type refreshTokenRepository struct{}
+
+// NewRefreshTokenRepository membuat instance RefreshTokenRepository baru.
+func NewRefreshTokenRepository() RefreshTokenRepository {
+	return &refreshTokenRepository{}
+}
+
+// Create menyimpan refresh token baru.
+func (r *refreshTokenRepository) Create(token *models.RefreshToken) error {
+	return config.DB.Create(token).Error
+}
+
+// FindByHash mencari refresh token berdasarkan hash SHA-256-nya.
+func (r *refreshTokenRepository) FindByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := config.DB.Where("token_hash = ?", hash).First(&token).Error
+	return &token, err
+}
+
+// Rotate mencabut token lama dan menyimpan token pengganti dalam SATU transaksi.
+//
+// UPDATE memakai kondisi "revoked_at IS NULL" sehingga hanya satu request yang bisa
+// merotasi token yang sama. Request kedua akan mendapat ErrRefreshTokenAlreadyUsed.
+func (r *refreshTokenRepository) Rotate(current *models.RefreshToken, next *models.RefreshToken) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.RefreshToken{}).
+			Where("internal_id = ? AND revoked_at IS NULL", current.InternalID).
+			Updates(map[string]interface{}{
+				"revoked_at":              time.Now(),
+				"replaced_by_internal_id": next.InternalID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Return error -> transaksi di-rollback, token baru ikut batal dibuat.
+			return ErrRefreshTokenAlreadyUsed
+		}
+		return nil
+	})
+}
+
+// RevokeFamily mencabut semua token yang masih aktif dalam satu family.
+func (r *refreshTokenRepository) RevokeFamily(familyID uuid.UUID) error {
+	return config.DB.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllForUser mencabut semua token aktif milik seorang user.
+func (r *refreshTokenRepository) RevokeAllForUser(userID int64) error {
+	return config.DB.Model(&models.RefreshToken{}).
+		Where("user_internal_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"gorm.io/gorm/clause"
+)
+
+// TokenRevocationRepository adalah kontrak operasi database untuk tabel
+// revoked_tokens dan user_token_revocations.
+type TokenRevocationRepository interface {
+	RevokeToken(token *models.RevokedToken) error
+	SetRevokedBefore(userID int64, revokedBefore time.Time) error
+	FindRevokedTokensSince(since time.Time) ([]models.RevokedToken, error)
+	FindUserRevocationsSince(since time.Time) ([]models.UserTokenRevocation, error)
+	DeleteExpired(now time.Time) error
+}
+
+type tokenRevocationRepository struct{}
+
+// NewTokenRevocationRepository membuat instance TokenRevocationRepository baru.
+func NewTokenRevocationRepository() TokenRevocationRepository {
+	return &tokenRevocationRepository{}
+}
+
+// RevokeToken menyimpan jti yang dicabut.
+// ON CONFLICT DO NOTHING: logout dua kali dengan token yang sama tidak dianggap error.
+func (r *tokenRevocationRepository) RevokeToken(token *models.RevokedToken) error {
+	return config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+// SetRevokedBefore membuat atau memperbarui batas pencabutan token milik user (UPSERT).
+func (r *tokenRevocationRepository) SetRevokedBefore(userID int64, revokedBefore time.Time) error {
+	row := models.UserTokenRevocation{UserID: userID, RevokedBefore: revokedBefore}
+	return config.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_internal_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_before", "updated_at"}),
+	}).Create(&row).Error
+}
+
+// FindRevokedTokensSince mengambil jti yang dicabut sejak waktu tertentu dan belum expired.
+func (r *tokenRevocationRepository) FindRevokedTokensSince(since time.Time) ([]models.RevokedToken, error) {
+	var tokens []models.RevokedToken
+	err := config.DB.
+		Where("revoked_at >= ? AND expires_at > ?", since, time.Now()).
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// FindUserRevocationsSince mengambil batas pencabutan per user yang berubah sejak waktu tertentu.
+func (r *tokenRevocationRepository) FindUserRevocationsSince(since time.Time) ([]models.UserTokenRevocation, error) {
+	var rows []models.UserTokenRevocation
+	err := config.DB.Where("updated_at >= ?", since).Find(&rows).Error
+	return rows, err
+}
+
+// DeleteExpired menghapus jti yang token aslinya sudah expired (tidak perlu diingat lagi).
+func (r *tokenRevocationRepository) DeleteExpired(now time.Time) error {
+	return config.DB.Where("expires_at <= ?", now).Delete(&models.RevokedToken{}).Error
+}
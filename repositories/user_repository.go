@@ -0,0 +1,72 @@
+// Package repositories berisi lapisan akses data (query ke database).
+// Repository TIDAK berisi aturan bisnis, hanya operasi baca/tulis ke tabel.
+// Aturan bisnis (validasi, hak akses, dll) ada di package services.
+package repositories
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/models"
+)
+
+// ErrEmailTaken dikembalikan Create jika email sudah dipakai user lain (constraint
+// user_email_unique). Ini menangkap register bersamaan dengan email yang sama, yang lolos
+// dari pengecekan FindByEmail di service.
+var ErrEmailTaken = errors.New("email is already taken")
+
+// userEmailUniqueConstraint adalah nama constraint UNIQUE pada users.email (migration 000018).
+const userEmailUniqueConstraint = "user_email_unique"
+
+// UserRepository adalah kontrak (interface) operasi database untuk tabel users.
+// Service bergantung pada interface ini, bukan pada struct konkret,
+// sehingga implementasinya mudah diganti (misal untuk mocking).
+type UserRepository interface {
+	Create(user *models.User) error
+	FindByEmail(email string) (*models.User, error)
+	FindByPublicID(publicID uuid.UUID) (*models.User, error)
+	FindByID(id int64) (*models.User, error)
+}
+
+// userRepository adalah implementasi UserRepository menggunakan GORM (config.DB).
+type userRepository struct{}
+
+// NewUserRepository membuat instance UserRepository baru.
+func NewUserRepository() UserRepository {
+	return &userRepository{}
+}
+
+// Create menyimpan user baru ke database.
+// Mengembalikan ErrEmailTaken jika email sudah terdaftar.
+func (r *userRepository) Create(user *models.User) error {
+	err := config.DB.Create(user).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == userEmailUniqueConstraint {
+		return ErrEmailTaken
+	}
+	return err
+}
+
+// FindByEmail mencari user berdasarkan email.
+// Mengembalikan gorm.ErrRecordNotFound jika tidak ada.
+func (r *userRepository) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	err := config.DB.Where("email = ?", email).First(&user).Error
+	return &user, err
+}
+
+// FindByPublicID mencari user berdasarkan PublicID (UUID).
+func (r *userRepository) FindByPublicID(publicID uuid.UUID) (*models.User, error) {
+	var user models.User
+	err := config.DB.Where("public_id = ?", publicID).First(&user).Error
+	return &user, err
+}
+
+// FindByID mencari user berdasarkan InternalID.
+func (r *userRepository) FindByID(id int64) (*models.User, error) {
+	var user models.User
+	err := config.DB.First(&user, id).Error
+	return &user, err
+}
@@ -0,0 +1,286 @@
+// Package routes berisi pendaftaran seluruh endpoint HTTP aplikasi.
+// Semua route dikelompokkan (group) berdasarkan model yang ada di package models,
+// sehingga mudah mencari di mana sebuah endpoint didaftarkan.
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/config"
+	"github.com/rakafajars/go-manajemen-project/controllers"
+	"github.com/rakafajars/go-manajemen-project/middlewares"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/services"
+	"github.com/rakafajars/go-manajemen-project/storage"
+)
+
+// Dependencies berisi komponen yang dibuat oleh cmd/server karena juga dipakai goroutine
+// background (sinkronisasi revocation, thumbnail worker) yang dijalankan dan ditunggu
+// oleh main sebelum pool database ditutup. Setup hanya memakainya, tidak menjalankan
+// goroutine apa pun.
+type Dependencies struct {
+	Revocations     services.TokenRevocationService // Cache token yang dicabut, disinkronkan berkala
+	Storage         storage.Storage                 // Penyimpanan file attachment (STORAGE_DRIVER)
+	ThumbnailWorker services.ThumbnailWorker        // Pembuat thumbnail attachment gambar
+}
+
+// Setup mendaftarkan semua route group ke instance Fiber.
+// Fungsi ini dipanggil sekali dari cmd/server setelah config, database, dan deps siap.
+//
+// Struktur URL:
+//
+//	/healthz, /readyz, /version     -> probe orchestrator & metadata build (tanpa auth)
+//	/api/auth                       -> register, login, profil (models.User)
+//	/api/users                      -> models.User (termasuk workload: kartu yang ditugaskan)
+//	/api/boards                     -> models.Board
+//	/api/boards/:id/members         -> models.BoardMember
+//	/api/boards/:id/invitations     -> models.BoardInvitation
+//	/api/invitations                -> daftar undangan milik user & terima/tolak models.BoardInvitation
+//	/api/boards/:id/lists           -> models.List & models.ListPosition
+//	/api/boards/:id/cards           -> models.Card (filter label: ?labels=&match=any|all)
+//	/api/boards/:id/labels          -> models.Label
+//	/api/lists                      -> models.List
+//	/api/lists/:id/cards            -> models.Card & models.CardPosition
+//	/api/cards                      -> models.Card
+//	/api/cards/:id/comments         -> models.Comment
+//	/api/cards/:id/labels           -> models.CardLabel
+//	/api/cards/:id/assignees        -> models.CardAssignee
+//	/api/cards/:id/attachments      -> upload & daftar models.CardAttachment
+//	/api/cards/:id/checklists       -> models.Checklist (beserta item-nya)
+//	/api/comments                   -> models.Comment & models.CommentRevision
+//	/api/labels                     -> models.Label
+//	/api/attachments                -> download, thumbnail & hapus models.CardAttachment
+//	/api/checklists                 -> models.Checklist
+//	/api/checklist-items            -> models.ChecklistItem
+func Setup(app *fiber.App, deps Dependencies) {
+	// Semua endpoint API diberi prefix /api agar terpisah dari endpoint lain
+	// (health check & version) yang ada di root.
+	api := app.Group("/api")
+
+	// Dependency injection manual: repository -> service -> controller.
+	userRepo := repositories.NewUserRepository()
+	refreshTokenRepo := repositories.NewRefreshTokenRepository()
+	sessionService := services.NewSessionService(userRepo, refreshTokenRepo, deps.Revocations)
+
+	authController := controllers.NewAuthController(services.NewAuthService(userRepo, refreshTokenRepo), sessionService)
+
+	// AuthorizationService adalah satu-satunya tempat aturan hak akses per board.
+	// Semua service board/list/card/comment memakai instance yang sama.
+	boardMemberRepo := repositories.NewBoardMemberRepository()
+	authzService := services.NewAuthorizationService(boardMemberRepo)
+
+	// Dipakai service board, list, dan kartu untuk menghapus file attachment saat
+	// board/list/kartunya dihapus.
+	attachmentRepo := repositories.NewCardAttachmentRepository()
+
+	boardRepo := repositories.NewBoardRepository()
+	boardService := services.NewBoardService(boardRepo, attachmentRepo, deps.Storage, authzService)
+	boardMemberService := services.NewBoardMemberService(
+		boardService,
+		boardRepo,
+		boardMemberRepo,
+		repositories.NewBoardInvitationRepository(),
+		userRepo,
+	)
+	boardController := controllers.NewBoardController(boardService)
+	boardMemberController := controllers.NewBoardMemberController(boardMemberService)
+
+	// Implementasi repository list & kartu dipilih sesuai ORDERING_STRATEGY.
+	// Service dan controller tidak perlu tahu bagaimana urutan disimpan.
+	listRepo := repositories.NewListRepository()
+	cardRepo := repositories.NewCardRepository()
+	if config.AppConfig.OrderingStrategy == config.OrderingRank {
+		listRepo = repositories.NewRankedListRepository()
+		cardRepo = repositories.NewRankedCardRepository()
+	}
+
+	listService := services.NewListService(listRepo, attachmentRepo, deps.Storage, boardService, authzService)
+	listController := controllers.NewListController(listService)
+
+	labelRepo := repositories.NewLabelRepository()
+	cardService := services.NewCardService(cardRepo, listRepo, labelRepo, attachmentRepo, deps.Storage, boardService, listService, authzService)
+	cardController := controllers.NewCardController(cardService)
+
+	labelController := controllers.NewLabelController(services.NewLabelService(labelRepo, boardService, cardService, authzService))
+
+	assigneeService := services.NewCardAssigneeService(repositories.NewCardAssigneeRepository(), boardMemberRepo, userRepo, cardService)
+	assigneeController := controllers.NewCardAssigneeController(assigneeService)
+	userController := controllers.NewUserController(sessionService, assigneeService)
+
+	commentService := services.NewCommentService(repositories.NewCommentRepository(), boardMemberRepo, cardService, authzService)
+	commentController := controllers.NewCommentController(commentService)
+
+	checklistService := services.NewChecklistService(repositories.NewChecklistRepository(), boardMemberRepo, userRepo, cardService)
+	checklistController := controllers.NewChecklistController(checklistService)
+
+	attachmentService := services.NewCardAttachmentService(attachmentRepo, cardService, deps.Storage, deps.ThumbnailWorker, config.AppConfig.MaxUploadSize)
+	attachmentController := controllers.NewCardAttachmentController(attachmentService)
+
+	healthController := controllers.NewHealthController(services.NewHealthService(repositories.NewHealthRepository(), deps.Storage))
+
+	// Middleware Protected memvalidasi JWT sekaligus mengecek daftar token yang sudah dicabut.
+	protected := middlewares.Protected(deps.Revocations)
+
+	// Endpoint probe didaftarkan di root tanpa middleware Protected, karena orchestrator
+	// tidak punya token.
+	registerHealthRoutes(app, healthController)
+
+	registerAuthRoutes(api.Group("/auth"), authController, protected)
+
+	// Semua resource di bawah ini wajib login: middleware Protected dipasang di level group
+	// sehingga setiap handler bisa langsung memakai middlewares.CurrentUser(c).
+	registerUserRoutes(api.Group("/users", protected), userController)
+	registerBoardRoutes(api.Group("/boards", protected), boardController, boardMemberController, listController, cardController, labelController)
+	registerInvitationRoutes(api.Group("/invitations", protected), boardMemberController)
+	registerListRoutes(api.Group("/lists", protected), listController, cardController)
+	registerCardRoutes(api.Group("/cards", protected), cardController, commentController, labelController, assigneeController, attachmentController, checklistController)
+	registerCommentRoutes(api.Group("/comments", protected), commentController)
+	registerLabelRoutes(api.Group("/labels", protected), labelController)
+	registerAttachmentRoutes(api.Group("/attachments", protected), attachmentController)
+	registerChecklistRoutes(api.Group("/checklists", protected), api.Group("/checklist-items", protected), checklistController)
+}
+
+// registerHealthRoutes mendaftarkan endpoint liveness, readiness, dan versi build.
+func registerHealthRoutes(router fiber.Router, ctrl *controllers.HealthController) {
+	router.Get("/healthz", ctrl.Healthz)
+	router.Get("/readyz", ctrl.Readyz)
+	router.Get("/version", ctrl.Version)
+}
+
+// registerAuthRoutes mendaftarkan endpoint autentikasi.
+// Register, login, dan refresh bisa diakses tanpa token; sisanya wajib login.
+func registerAuthRoutes(router fiber.Router, ctrl *controllers.AuthController, protected fiber.Handler) {
+	router.Post("/register", ctrl.Register)
+	router.Post("/login", ctrl.Login)
+	router.Post("/refresh", ctrl.Refresh)
+	router.Post("/logout", protected, ctrl.Logout)
+	router.Post("/logout-all", protected, ctrl.LogoutAll)
+	router.Get("/me", protected, ctrl.Me)
+}
+
+// registerUserRoutes mendaftarkan endpoint untuk models.User.
+func registerUserRoutes(router fiber.Router, ctrl *controllers.UserController) {
+	router.Post("/:id/revoke-tokens", middlewares.RequireRole("admin"), ctrl.RevokeTokens)
+	router.Get("/:id/workload", ctrl.Workload)
+}
+
+// registerBoardRoutes mendaftarkan endpoint untuk models.Board beserta
+// resource turunannya (member dan urutan list).
+func registerBoardRoutes(
+	router fiber.Router,
+	ctrl *controllers.BoardController,
+	memberCtrl *controllers.BoardMemberController,
+	listCtrl *controllers.ListController,
+	cardCtrl *controllers.CardController,
+	labelCtrl *controllers.LabelController,
+) {
+	router.Post("/", ctrl.Create)
+	router.Get("/", ctrl.List)
+	router.Get("/:id", ctrl.Get)
+	router.Put("/:id", ctrl.Update)
+	router.Delete("/:id", ctrl.Delete)
+
+	router.Get("/:id/members", memberCtrl.ListMembers)
+	router.Patch("/:id/members/:user_id", memberCtrl.UpdateMemberRole)
+	router.Delete("/:id/members/:user_id", memberCtrl.RemoveMember)
+	router.Post("/:id/invitations", memberCtrl.Invite)
+	router.Get("/:id/invitations", memberCtrl.ListInvitations)
+	router.Delete("/:id/invitations/:invitation_id", memberCtrl.RevokeInvitation)
+
+	router.Post("/:id/lists", listCtrl.Create)
+	router.Get("/:id/lists", listCtrl.ListByBoard)
+
+	router.Get("/:id/cards", cardCtrl.ListByBoard)
+
+	router.Post("/:id/labels", labelCtrl.Create)
+	router.Get("/:id/labels", labelCtrl.ListByBoard)
+}
+
+// registerInvitationRoutes mendaftarkan endpoint untuk user yang diundang (models.BoardInvitation):
+// melihat undangan untuk email-nya, lalu menjawab dengan ID undangan atau dengan token.
+// Token undangan dikirim lewat body, bukan URL, agar tidak ikut tercatat di access log.
+func registerInvitationRoutes(router fiber.Router, ctrl *controllers.BoardMemberController) {
+	router.Get("/", ctrl.ListMine)
+	router.Post("/accept", ctrl.Accept)
+	router.Post("/decline", ctrl.Decline)
+	router.Post("/:id/accept", ctrl.AcceptByID)
+	router.Post("/:id/decline", ctrl.DeclineByID)
+}
+
+// registerListRoutes mendaftarkan endpoint untuk models.List beserta
+// kartu-kartu di dalamnya (models.Card & models.CardPosition).
+func registerListRoutes(router fiber.Router, ctrl *controllers.ListController, cardCtrl *controllers.CardController) {
+	router.Patch("/:id", ctrl.Update)
+	router.Delete("/:id", ctrl.Delete)
+	router.Patch("/:id/move", ctrl.Move)
+
+	router.Post("/:id/cards", cardCtrl.Create)
+	router.Get("/:id/cards", cardCtrl.ListByList)
+}
+
+// registerCardRoutes mendaftarkan endpoint untuk models.Card beserta
+// label, assignee, attachment, dan checklist yang menempel di kartu.
+func registerCardRoutes(
+	router fiber.Router,
+	ctrl *controllers.CardController,
+	commentCtrl *controllers.CommentController,
+	labelCtrl *controllers.LabelController,
+	assigneeCtrl *controllers.CardAssigneeController,
+	attachmentCtrl *controllers.CardAttachmentController,
+	checklistCtrl *controllers.ChecklistController,
+) {
+	router.Get("/:id", ctrl.Get)
+	router.Patch("/:id", ctrl.Update)
+	router.Delete("/:id", ctrl.Delete)
+	router.Patch("/:id/move", ctrl.Move)
+
+	router.Post("/:id/comments", commentCtrl.Create)
+	router.Get("/:id/comments", commentCtrl.ListByCard)
+
+	router.Get("/:id/labels", labelCtrl.ListByCard)
+	router.Put("/:id/labels/:label_id", labelCtrl.Attach)
+	router.Delete("/:id/labels/:label_id", labelCtrl.Detach)
+
+	router.Get("/:id/assignees", assigneeCtrl.ListByCard)
+	router.Put("/:id/assignees/:user_id", assigneeCtrl.Assign)
+	router.Delete("/:id/assignees/:user_id", assigneeCtrl.Unassign)
+
+	router.Post("/:id/attachments", attachmentCtrl.Upload)
+	router.Get("/:id/attachments", attachmentCtrl.ListByCard)
+
+	router.Post("/:id/checklists", checklistCtrl.Create)
+	router.Get("/:id/checklists", checklistCtrl.ListByCard)
+}
+
+// registerCommentRoutes mendaftarkan endpoint untuk models.Comment
+// beserta riwayat editnya (models.CommentRevision).
+func registerCommentRoutes(router fiber.Router, ctrl *controllers.CommentController) {
+	router.Patch("/:id", ctrl.Update)
+	router.Delete("/:id", ctrl.Delete)
+	router.Get("/:id/revisions", ctrl.ListRevisions)
+}
+
+// registerLabelRoutes mendaftarkan endpoint untuk models.Label.
+// Membuat dan melihat label ada di /boards/:id/labels karena label dimiliki board.
+func registerLabelRoutes(router fiber.Router, ctrl *controllers.LabelController) {
+	router.Patch("/:id", ctrl.Update)
+	router.Delete("/:id", ctrl.Delete)
+}
+
+// registerAttachmentRoutes mendaftarkan endpoint untuk models.CardAttachment.
+// Upload dan daftar attachment ada di /cards/:id/attachments karena attachment dimiliki kartu.
+func registerAttachmentRoutes(router fiber.Router, ctrl *controllers.CardAttachmentController) {
+	router.Get("/:id/download", ctrl.Download)
+	router.Get("/:id/thumbnail", ctrl.Thumbnail)
+	router.Delete("/:id", ctrl.Delete)
+}
+
+// registerChecklistRoutes mendaftarkan endpoint untuk models.Checklist dan models.ChecklistItem.
+// Membuat dan melihat checklist ada di /cards/:id/checklists karena checklist dimiliki kartu.
+func registerChecklistRoutes(router fiber.Router, itemRouter fiber.Router, ctrl *controllers.ChecklistController) {
+	router.Patch("/:id", ctrl.Update)
+	router.Delete("/:id", ctrl.Delete)
+	router.Post("/:id/items", ctrl.CreateItem)
+
+	itemRouter.Patch("/:id", ctrl.UpdateItem)
+	itemRouter.Delete("/:id", ctrl.DeleteItem)
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// AuthResult adalah hasil dari proses register/login/refresh:
+// access token JWT (berumur pendek), refresh token opaque, beserta profil publik user.
+type AuthResult struct {
+	AccessToken  string       `json:"access_token"`
+	RefreshToken string       `json:"refresh_token"`
+	TokenType    string       `json:"token_type"`
+	User         *models.User `json:"user"`
+}
+
+// AuthService berisi aturan bisnis autentikasi (register, login, profil).
+type AuthService interface {
+	Register(name, email, password string) (*AuthResult, error)
+	Login(email, password string) (*AuthResult, error)
+	Refresh(refreshToken string) (*AuthResult, error)
+	Me(publicID uuid.UUID) (*models.User, error)
+}
+
+type authService struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+}
+
+// NewAuthService membuat AuthService dengan dependency repository user dan refresh token.
+func NewAuthService(userRepo repositories.UserRepository, refreshTokenRepo repositories.RefreshTokenRepository) AuthService {
+	return &authService{userRepo: userRepo, refreshTokenRepo: refreshTokenRepo}
+}
+
+// Register membuat user baru dengan role "user" lalu langsung memberikan token.
+func (s *authService) Register(name, email, password string) (*AuthResult, error) {
+	email = normalizeEmail(email)
+
+	// Cek dulu apakah email sudah dipakai, supaya pesan error-nya jelas
+	// (bukan sekadar error constraint dari database).
+	if _, err := s.userRepo.FindByEmail(email); err == nil {
+		return nil, ErrEmailAlreadyUsed
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Name:     strings.TrimSpace(name),
+		Email:    email,
+		Password: hashed,
+		Role:     "user",
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		// Dua register bersamaan dengan email yang sama bisa sama-sama lolos pengecekan di atas;
+		// yang kalah ditolak oleh constraint UNIQUE di database.
+		if errors.Is(err, repositories.ErrEmailTaken) {
+			return nil, ErrEmailAlreadyUsed
+		}
+		return nil, err
+	}
+
+	return s.issue(user, uuid.New())
+}
+
+// Login mencocokkan email & password. Pesan error dibuat sama untuk
+// "email tidak ada" dan "password salah" agar penyerang tidak bisa menebak email terdaftar.
+func (s *authService) Login(email, password string) (*AuthResult, error) {
+	user, err := s.userRepo.FindByEmail(normalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Tetap jalankan bcrypt agar waktu respons sama dengan "password salah";
+			// tanpa ini, respons yang lebih cepat membocorkan email mana yang tidak terdaftar.
+			utils.CheckPasswordHash(password, dummyPasswordHash)
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if !utils.CheckPasswordHash(password, user.Password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	// Setiap login memulai "family" refresh token baru.
+	return s.issue(user, uuid.New())
+}
+
+// Refresh menukar refresh token dengan pasangan access token + refresh token baru (rotasi).
+//
+// Aturan:
+//   - Token tidak ditemukan / expired -> ErrInvalidRefreshToken
+//   - Token sudah pernah dipakai (revoked) -> dianggap dicuri, seluruh family dicabut -> ErrRefreshTokenReused
+func (s *authService) Refresh(refreshToken string) (*AuthResult, error) {
+	current, err := s.refreshTokenRepo.FindByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		return nil, s.revokeReusedFamily(current)
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.FindByID(current.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	plain, next, err := newRefreshToken(user.InternalID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.Rotate(current, next); err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenAlreadyUsed) {
+			return nil, s.revokeReusedFamily(current)
+		}
+		return nil, err
+	}
+
+	accessToken, err := utils.GenerateToken(user.InternalID, user.Role, user.Email, user.PublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResult{
+		AccessToken:  accessToken,
+		RefreshToken: plain,
+		TokenType:    "Bearer",
+		User:         user,
+	}, nil
+}
+
+// Me mengambil profil user yang sedang login berdasarkan PublicID dari token.
+func (s *authService) Me(publicID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByPublicID(publicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// revokeReusedFamily mencabut seluruh family token saat terdeteksi reuse.
+func (s *authService) revokeReusedFamily(token *models.RefreshToken) error {
+	if err := s.refreshTokenRepo.RevokeFamily(token.FamilyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// issue membuat access token JWT + refresh token baru (dalam family yang diberikan)
+// dan membungkusnya dalam AuthResult.
+func (s *authService) issue(user *models.User, familyID uuid.UUID) (*AuthResult, error) {
+	accessToken, err := utils.GenerateToken(user.InternalID, user.Role, user.Email, user.PublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, refresh, err := newRefreshToken(user.InternalID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.Create(refresh); err != nil {
+		return nil, err
+	}
+
+	return &AuthResult{
+		AccessToken:  accessToken,
+		RefreshToken: plain,
+		TokenType:    "Bearer",
+		User:         user,
+	}, nil
+}
+
+// newRefreshToken menyiapkan row RefreshToken baru (belum disimpan) beserta token aslinya.
+func newRefreshToken(userID int64, familyID uuid.UUID) (string, *models.RefreshToken, error) {
+	plain, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return plain, &models.RefreshToken{
+		FamilyID:  familyID,
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL()),
+	}, nil
+}
+
+// dummyPasswordHash adalah hash bcrypt (cost default, sama dengan HashPassword) yang
+// dibandingkan saat email login tidak terdaftar. Hash ini tidak milik akun mana pun.
+const dummyPasswordHash = "$2a$10$H8PPAsO/PFE2tz6u4Mjzj.4rnX83Vjh.CGEa7loRfh/1aQuXz2/jy"
+
+// normalizeEmail menyeragamkan format email (tanpa spasi, huruf kecil)
+// agar "John@Mail.com" dan "john@mail.com" dianggap sama.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
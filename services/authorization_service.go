@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// Permission adalah aksi yang bisa dilakukan di dalam sebuah board.
+// Setiap handler board/list/card/comment WAJIB mengecek permission lewat AuthorizationService,
+// bukan mengecek role secara manual, agar aturan hak akses hanya ada di satu tempat.
+type Permission string
+
+const (
+	PermBoardView       Permission = "board:view"       // Melihat board beserta isinya
+	PermBoardUpdate     Permission = "board:update"     // Mengubah judul/deskripsi/due date board
+	PermBoardDelete     Permission = "board:delete"     // Menghapus board
+	PermMemberManage    Permission = "member:manage"    // Mengundang, mengeluarkan, dan mengubah role member
+	PermListWrite       Permission = "list:write"       // Membuat, mengubah, menghapus, dan mengurutkan list
+	PermCardWrite       Permission = "card:write"       // Membuat, mengubah, menghapus, dan memindahkan kartu
+	PermLabelManage     Permission = "label:manage"     // Membuat, mengubah, dan menghapus label board
+	PermCommentCreate   Permission = "comment:create"   // Menulis komentar
+	PermCommentModerate Permission = "comment:moderate" // Menghapus komentar milik orang lain
+)
+
+// rolePermissions adalah matriks hak akses setiap role board.
+var rolePermissions = map[string][]Permission{
+	models.BoardRoleOwner: {
+		PermBoardView, PermBoardUpdate, PermBoardDelete, PermMemberManage,
+		PermListWrite, PermCardWrite, PermLabelManage, PermCommentCreate, PermCommentModerate,
+	},
+	models.BoardRoleAdmin: {
+		PermBoardView, PermBoardUpdate, PermMemberManage,
+		PermListWrite, PermCardWrite, PermLabelManage, PermCommentCreate, PermCommentModerate,
+	},
+	models.BoardRoleEditor: {
+		PermBoardView, PermListWrite, PermCardWrite, PermLabelManage, PermCommentCreate,
+	},
+	models.BoardRoleCommenter: {
+		PermBoardView, PermCommentCreate,
+	},
+	models.BoardRoleViewer: {
+		PermBoardView,
+	},
+}
+
+// roleRank dipakai untuk membandingkan "tinggi" role, misalnya saat mengubah role member lain.
+var roleRank = map[string]int{
+	models.BoardRoleViewer:    1,
+	models.BoardRoleCommenter: 2,
+	models.BoardRoleEditor:    3,
+	models.BoardRoleAdmin:     4,
+	models.BoardRoleOwner:     5,
+}
+
+// RoleHasPermission mengecek apakah role board memiliki permission tertentu.
+func RoleHasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorizationService adalah lapisan otorisasi terpusat untuk semua resource di dalam board.
+type AuthorizationService interface {
+	// Authorize memastikan principal boleh melakukan permission di board,
+	// lalu mengembalikan role efektif principal di board tersebut.
+	//
+	// Error yang mungkin:
+	//   - ErrBoardNotFound: principal bukan member (keberadaan board disembunyikan)
+	//   - ErrForbidden: principal member, tetapi role-nya tidak punya permission
+	Authorize(principal *utils.Principal, boardID int64, permission Permission) (string, error)
+}
+
+type authorizationService struct {
+	memberRepo repositories.BoardMemberRepository
+}
+
+// NewAuthorizationService membuat AuthorizationService dengan dependency BoardMemberRepository.
+func NewAuthorizationService(memberRepo repositories.BoardMemberRepository) AuthorizationService {
+	return &authorizationService{memberRepo: memberRepo}
+}
+
+// Authorize mengecek permission berdasarkan role member di board.
+// Admin global (models.User.Role == "admin") selalu diizinkan.
+func (s *authorizationService) Authorize(principal *utils.Principal, boardID int64, permission Permission) (string, error) {
+	member, err := s.memberRepo.Find(boardID, principal.InternalID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	isMember := err == nil
+
+	if principal.IsAdmin() {
+		// Admin global minimal diperlakukan sebagai admin board.
+		if isMember && member.Role == models.BoardRoleOwner {
+			return models.BoardRoleOwner, nil
+		}
+		return models.BoardRoleAdmin, nil
+	}
+
+	if !isMember {
+		return "", ErrBoardNotFound
+	}
+	if !RoleHasPermission(member.Role, permission) {
+		return member.Role, ErrForbidden
+	}
+	return member.Role, nil
+}
@@ -0,0 +1,393 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// invitationTTL adalah masa berlaku token undangan board.
+const invitationTTL = 7 * 24 * time.Hour
+
+// InviteMemberInput adalah data untuk mengundang user ke board.
+// Role boleh kosong (default: editor).
+type InviteMemberInput struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// UpdateMemberRoleInput adalah data untuk mengubah role member.
+type UpdateMemberRoleInput struct {
+	Role string `json:"role"`
+}
+
+// RespondInvitationInput adalah data untuk menerima/menolak undangan.
+type RespondInvitationInput struct {
+	Token string `json:"token"`
+}
+
+// InvitationResult adalah hasil pembuatan undangan.
+// Token hanya dikembalikan SEKALI di sini; database hanya menyimpan hash-nya.
+// User yang diundang tidak butuh token ini: setelah login dengan email yang diundang,
+// ia bisa melihat undangannya lewat ListMyInvitations dan menjawab dengan ID undangan.
+type InvitationResult struct {
+	Invitation *models.BoardInvitation `json:"invitation"`
+	Token      string                  `json:"token"`
+}
+
+// BoardMemberService berisi aturan bisnis keanggotaan board dan undangan.
+type BoardMemberService interface {
+	Invite(principal *utils.Principal, boardPublicID uuid.UUID, input InviteMemberInput) (*InvitationResult, error)
+	ListInvitations(principal *utils.Principal, boardPublicID uuid.UUID) ([]models.BoardInvitation, error)
+	RevokeInvitation(principal *utils.Principal, boardPublicID, invitationPublicID uuid.UUID) error
+	AcceptInvitation(principal *utils.Principal, token string) (*models.Board, error)
+	DeclineInvitation(principal *utils.Principal, token string) error
+	ListMyInvitations(principal *utils.Principal) ([]models.BoardInvitation, error)
+	AcceptInvitationByID(principal *utils.Principal, invitationPublicID uuid.UUID) (*models.Board, error)
+	DeclineInvitationByID(principal *utils.Principal, invitationPublicID uuid.UUID) error
+	ListMembers(principal *utils.Principal, boardPublicID uuid.UUID) ([]models.BoardMemberDetail, error)
+	UpdateMemberRole(principal *utils.Principal, boardPublicID, userPublicID uuid.UUID, input UpdateMemberRoleInput) error
+	RemoveMember(principal *utils.Principal, boardPublicID, userPublicID uuid.UUID) error
+}
+
+type boardMemberService struct {
+	boardService   BoardService
+	boardRepo      repositories.BoardRepository
+	memberRepo     repositories.BoardMemberRepository
+	invitationRepo repositories.BoardInvitationRepository
+	userRepo       repositories.UserRepository
+}
+
+// NewBoardMemberService membuat BoardMemberService dengan dependency yang dibutuhkan.
+func NewBoardMemberService(
+	boardService BoardService,
+	boardRepo repositories.BoardRepository,
+	memberRepo repositories.BoardMemberRepository,
+	invitationRepo repositories.BoardInvitationRepository,
+	userRepo repositories.UserRepository,
+) BoardMemberService {
+	return &boardMemberService{
+		boardService:   boardService,
+		boardRepo:      boardRepo,
+		memberRepo:     memberRepo,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// Invite membuat undangan untuk email tertentu. Butuh permission PermMemberManage.
+// Undangan lama (pending) untuk email yang sama otomatis dicabut.
+func (s *boardMemberService) Invite(principal *utils.Principal, boardPublicID uuid.UUID, input InviteMemberInput) (*InvitationResult, error) {
+	board, actorRole, err := s.boardService.GetAuthorized(principal, boardPublicID, PermMemberManage)
+	if err != nil {
+		return nil, err
+	}
+
+	role := input.Role
+	if role == "" {
+		role = models.BoardRoleEditor
+	}
+	if err := validateAssignableRole(principal, actorRole, role); err != nil {
+		return nil, err
+	}
+
+	if _, err := mail.ParseAddress(input.Email); err != nil {
+		return nil, fmt.Errorf("%w: email is not valid", ErrInvalidInput)
+	}
+	email := normalizeEmail(input.Email)
+
+	// Jika email sudah terdaftar dan user-nya sudah member, undangan tidak perlu dibuat.
+	if user, err := s.userRepo.FindByEmail(email); err == nil {
+		if _, err := s.memberRepo.Find(board.InternalID, user.InternalID); err == nil {
+			return nil, ErrAlreadyMember
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err := s.invitationRepo.RevokePendingByEmail(board.InternalID, email); err != nil {
+		return nil, err
+	}
+
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &models.BoardInvitation{
+		BoardID:       board.InternalID,
+		BoardPublicID: board.PublicID,
+		Email:         email,
+		Role:          role,
+		InvitedByID:   principal.InternalID,
+		TokenHash:     hash,
+		Status:        models.InvitationStatusPending,
+		ExpiresAt:     time.Now().Add(invitationTTL),
+	}
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return nil, err
+	}
+
+	return &InvitationResult{Invitation: invitation, Token: token}, nil
+}
+
+// ListInvitations mengambil undangan yang masih pending di sebuah board.
+func (s *boardMemberService) ListInvitations(principal *utils.Principal, boardPublicID uuid.UUID) ([]models.BoardInvitation, error) {
+	board, _, err := s.boardService.GetAuthorized(principal, boardPublicID, PermMemberManage)
+	if err != nil {
+		return nil, err
+	}
+	return s.invitationRepo.FindPendingByBoard(board.InternalID)
+}
+
+// RevokeInvitation membatalkan undangan yang belum direspon.
+func (s *boardMemberService) RevokeInvitation(principal *utils.Principal, boardPublicID, invitationPublicID uuid.UUID) error {
+	board, _, err := s.boardService.GetAuthorized(principal, boardPublicID, PermMemberManage)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := s.invitationRepo.FindByPublicID(invitationPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvitationNotFound
+		}
+		return err
+	}
+	if invitation.BoardID != board.InternalID {
+		return ErrInvitationNotFound
+	}
+
+	return translateInvitationErr(s.invitationRepo.Respond(invitation, models.InvitationStatusRevoked))
+}
+
+// AcceptInvitation menerima undangan (dicari dari token) dan menjadikan user yang login
+// sebagai member board.
+func (s *boardMemberService) AcceptInvitation(principal *utils.Principal, token string) (*models.Board, error) {
+	invitation, err := s.findInvitationByToken(principal, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.accept(principal, invitation)
+}
+
+// DeclineInvitation menolak undangan (dicari dari token).
+func (s *boardMemberService) DeclineInvitation(principal *utils.Principal, token string) error {
+	invitation, err := s.findInvitationByToken(principal, token)
+	if err != nil {
+		return err
+	}
+	return translateInvitationErr(s.invitationRepo.Respond(invitation, models.InvitationStatusDeclined))
+}
+
+// ListMyInvitations mengambil undangan pending (di semua board) yang ditujukan ke email
+// user yang sedang login.
+func (s *boardMemberService) ListMyInvitations(principal *utils.Principal) ([]models.BoardInvitation, error) {
+	return s.invitationRepo.FindPendingByEmail(normalizeEmail(principal.Email))
+}
+
+// AcceptInvitationByID sama dengan AcceptInvitation, tetapi undangan dicari dari PublicID-nya.
+// Aman tanpa token karena undangan hanya bisa diterima oleh user dengan email yang diundang.
+func (s *boardMemberService) AcceptInvitationByID(principal *utils.Principal, invitationPublicID uuid.UUID) (*models.Board, error) {
+	invitation, err := s.findInvitationByID(principal, invitationPublicID)
+	if err != nil {
+		return nil, err
+	}
+	return s.accept(principal, invitation)
+}
+
+// DeclineInvitationByID sama dengan DeclineInvitation, tetapi undangan dicari dari PublicID-nya.
+func (s *boardMemberService) DeclineInvitationByID(principal *utils.Principal, invitationPublicID uuid.UUID) error {
+	invitation, err := s.findInvitationByID(principal, invitationPublicID)
+	if err != nil {
+		return err
+	}
+	return translateInvitationErr(s.invitationRepo.Respond(invitation, models.InvitationStatusDeclined))
+}
+
+// accept menandai undangan sebagai accepted dan memasukkan user sebagai member board.
+func (s *boardMemberService) accept(principal *utils.Principal, invitation *models.BoardInvitation) (*models.Board, error) {
+	member := &models.BoardMember{
+		BoardID:  invitation.BoardID,
+		UserID:   principal.InternalID,
+		Role:     invitation.Role,
+		JoinedAt: time.Now(),
+	}
+	if err := s.invitationRepo.Accept(invitation, member); err != nil {
+		return nil, translateInvitationErr(err)
+	}
+
+	return s.boardRepo.FindByID(invitation.BoardID)
+}
+
+// ListMembers mengambil daftar member board. Semua member boleh melihat.
+func (s *boardMemberService) ListMembers(principal *utils.Principal, boardPublicID uuid.UUID) ([]models.BoardMemberDetail, error) {
+	board, err := s.boardService.GetByPublicID(principal, boardPublicID)
+	if err != nil {
+		return nil, err
+	}
+	return s.memberRepo.ListByBoard(board.InternalID)
+}
+
+// UpdateMemberRole mengubah role member. Butuh permission PermMemberManage, dan
+// hanya boleh mengubah member yang role-nya lebih rendah dari role pelaku.
+func (s *boardMemberService) UpdateMemberRole(principal *utils.Principal, boardPublicID, userPublicID uuid.UUID, input UpdateMemberRoleInput) error {
+	board, actorRole, err := s.boardService.GetAuthorized(principal, boardPublicID, PermMemberManage)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.findMember(board.InternalID, userPublicID)
+	if err != nil {
+		return err
+	}
+	if member.Role == models.BoardRoleOwner {
+		return ErrCannotChangeOwner
+	}
+	if !outranks(principal, actorRole, member.Role) {
+		return ErrForbidden
+	}
+	if err := validateAssignableRole(principal, actorRole, input.Role); err != nil {
+		return err
+	}
+
+	return s.memberRepo.UpdateRole(board.InternalID, member.UserID, input.Role)
+}
+
+// RemoveMember mengeluarkan member dari board.
+// Member selalu boleh keluar sendiri. Mengeluarkan orang lain butuh PermMemberManage
+// dan role target harus lebih rendah dari role pelaku. Owner tidak bisa dikeluarkan.
+func (s *boardMemberService) RemoveMember(principal *utils.Principal, boardPublicID, userPublicID uuid.UUID) error {
+	board, actorRole, err := s.boardService.GetAuthorized(principal, boardPublicID, PermBoardView)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.findMember(board.InternalID, userPublicID)
+	if err != nil {
+		return err
+	}
+	if member.Role == models.BoardRoleOwner {
+		return ErrCannotRemoveOwner
+	}
+
+	isSelf := member.UserID == principal.InternalID
+	if !isSelf {
+		canManage := principal.IsAdmin() || RoleHasPermission(actorRole, PermMemberManage)
+		if !canManage || !outranks(principal, actorRole, member.Role) {
+			return ErrForbidden
+		}
+	}
+
+	return s.memberRepo.Remove(board.InternalID, member.UserID)
+}
+
+// findMember mencari keanggotaan user (berdasarkan PublicID user) di sebuah board.
+func (s *boardMemberService) findMember(boardID int64, userPublicID uuid.UUID) (*models.BoardMember, error) {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	member, err := s.memberRepo.Find(boardID, user.InternalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+// validateAssignableRole memastikan role valid dan boleh diberikan oleh pelaku.
+// Role owner tidak bisa diberikan lewat undangan/ubah role, dan pelaku hanya boleh
+// memberi role di bawah role-nya sendiri (kecuali admin global).
+func validateAssignableRole(principal *utils.Principal, actorRole, role string) error {
+	if !models.IsValidBoardRole(role) || role == models.BoardRoleOwner {
+		return fmt.Errorf("%w: role must be one of admin, editor, commenter, viewer", ErrInvalidInput)
+	}
+	if !outranks(principal, actorRole, role) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// outranks mengecek apakah pelaku (dengan actorRole) lebih tinggi dari targetRole.
+// Admin global selalu dianggap lebih tinggi dari role apapun selain owner.
+func outranks(principal *utils.Principal, actorRole, targetRole string) bool {
+	if principal.IsAdmin() && targetRole != models.BoardRoleOwner {
+		return true
+	}
+	return roleRank[actorRole] > roleRank[targetRole]
+}
+
+// findInvitationByToken mencari undangan berdasarkan token lalu memeriksanya dengan checkInvitation.
+func (s *boardMemberService) findInvitationByToken(principal *utils.Principal, token string) (*models.BoardInvitation, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: token is required", ErrInvalidInput)
+	}
+
+	invitation, err := s.invitationRepo.FindByTokenHash(utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return invitation, checkInvitation(principal, invitation)
+}
+
+// findInvitationByID mencari undangan berdasarkan PublicID lalu memeriksanya dengan checkInvitation.
+// Undangan untuk email lain dilaporkan sebagai tidak ditemukan, agar ID undangan orang lain
+// tidak bisa dipakai untuk menebak isinya.
+func (s *boardMemberService) findInvitationByID(principal *utils.Principal, invitationPublicID uuid.UUID) (*models.BoardInvitation, error) {
+	invitation, err := s.invitationRepo.FindByPublicID(invitationPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	if err := checkInvitation(principal, invitation); err != nil {
+		if errors.Is(err, ErrInvitationEmailMismatch) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// checkInvitation memastikan undangan ditujukan ke email user yang sedang login,
+// masih pending, dan belum expired.
+func checkInvitation(principal *utils.Principal, invitation *models.BoardInvitation) error {
+	if invitation.Email != normalizeEmail(principal.Email) {
+		return ErrInvitationEmailMismatch
+	}
+	if invitation.Status != models.InvitationStatusPending {
+		return ErrInvitationNotPending
+	}
+	if time.Now().After(invitation.ExpiresAt) {
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+// translateInvitationErr mengubah error repository menjadi error service.
+func translateInvitationErr(err error) error {
+	if errors.Is(err, repositories.ErrInvitationNotPending) {
+		return ErrInvitationNotPending
+	}
+	return err
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/storage"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// CreateBoardInput adalah data yang dibutuhkan untuk membuat board.
+type CreateBoardInput struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	DueDate     *time.Time `json:"due_date"`
+}
+
+// UpdateBoardInput adalah data untuk mengubah board.
+// Semua field berupa pointer: nil artinya "tidak diubah".
+// ClearDueDate dipakai untuk menghapus due date (karena DueDate nil berarti "tidak diubah").
+type UpdateBoardInput struct {
+	Title        *string    `json:"title"`
+	Description  *string    `json:"description"`
+	DueDate      *time.Time `json:"due_date"`
+	ClearDueDate bool       `json:"clear_due_date"`
+}
+
+// BoardService berisi aturan bisnis untuk board.
+type BoardService interface {
+	Create(principal *utils.Principal, input CreateBoardInput) (*models.Board, error)
+	GetByPublicID(principal *utils.Principal, publicID uuid.UUID) (*models.Board, error)
+	GetAuthorized(principal *utils.Principal, publicID uuid.UUID, permission Permission) (*models.Board, string, error)
+	List(principal *utils.Principal, page, limit int) ([]models.Board, int64, error)
+	Update(principal *utils.Principal, publicID uuid.UUID, input UpdateBoardInput) (*models.Board, error)
+	Delete(principal *utils.Principal, publicID uuid.UUID) error
+}
+
+type boardService struct {
+	boardRepo      repositories.BoardRepository
+	attachmentRepo repositories.CardAttachmentRepository
+	storage        storage.Storage
+	authz          AuthorizationService
+}
+
+// NewBoardService membuat BoardService dengan dependency yang dibutuhkan.
+// attachmentRepo dan store dipakai untuk menghapus file attachment saat board dihapus.
+func NewBoardService(
+	boardRepo repositories.BoardRepository,
+	attachmentRepo repositories.CardAttachmentRepository,
+	store storage.Storage,
+	authz AuthorizationService,
+) BoardService {
+	return &boardService{boardRepo: boardRepo, attachmentRepo: attachmentRepo, storage: store, authz: authz}
+}
+
+// Create membuat board baru dengan user yang sedang login sebagai owner.
+func (s *boardService) Create(principal *utils.Principal, input CreateBoardInput) (*models.Board, error) {
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+
+	board := &models.Board{
+		Title:         title,
+		Description:   strings.TrimSpace(input.Description),
+		OwnerID:       principal.InternalID,
+		OwnerPublicID: principal.PublicID,
+		DueDate:       input.DueDate,
+	}
+	if err := s.boardRepo.CreateWithOwner(board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// GetByPublicID mengambil detail board. Semua member board (atau admin) boleh melihat.
+// Non-member mendapat ErrBoardNotFound agar keberadaan board tidak bocor.
+func (s *boardService) GetByPublicID(principal *utils.Principal, publicID uuid.UUID) (*models.Board, error) {
+	board, _, err := s.GetAuthorized(principal, publicID, PermBoardView)
+	return board, err
+}
+
+// GetAuthorized mengambil board sekaligus memastikan principal punya permission tertentu.
+// Mengembalikan juga role efektif principal di board tersebut.
+func (s *boardService) GetAuthorized(principal *utils.Principal, publicID uuid.UUID, permission Permission) (*models.Board, string, error) {
+	board, err := s.findBoard(publicID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	role, err := s.authz.Authorize(principal, board.InternalID, permission)
+	if err != nil {
+		return nil, "", err
+	}
+	return board, role, nil
+}
+
+// List mengambil semua board di mana user yang sedang login menjadi member.
+func (s *boardService) List(principal *utils.Principal, page, limit int) ([]models.Board, int64, error) {
+	return s.boardRepo.FindAllByMember(principal.InternalID, page, limit)
+}
+
+// Update mengubah board. Butuh permission PermBoardUpdate (owner/admin board).
+func (s *boardService) Update(principal *utils.Principal, publicID uuid.UUID, input UpdateBoardInput) (*models.Board, error) {
+	board, _, err := s.GetAuthorized(principal, publicID, PermBoardUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		title := strings.TrimSpace(*input.Title)
+		if title == "" {
+			return nil, fmt.Errorf("%w: title cannot be empty", ErrInvalidInput)
+		}
+		board.Title = title
+	}
+	if input.Description != nil {
+		board.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.ClearDueDate {
+		board.DueDate = nil
+	} else if input.DueDate != nil {
+		board.DueDate = input.DueDate
+	}
+	board.UpdatedAt = time.Now()
+
+	if err := s.boardRepo.Update(board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// Delete menghapus board. Butuh permission PermBoardDelete (hanya owner, atau admin global).
+func (s *boardService) Delete(principal *utils.Principal, publicID uuid.UUID) error {
+	board, _, err := s.GetAuthorized(principal, publicID, PermBoardDelete)
+	if err != nil {
+		return err
+	}
+	attachments, err := s.attachmentRepo.FindByBoard(board.InternalID)
+	if err != nil {
+		return err
+	}
+	if err := s.boardRepo.Delete(board); err != nil {
+		return err
+	}
+	deleteAttachmentObjects(s.storage, attachments)
+	return nil
+}
+
+// findBoard mencari board dan menerjemahkan gorm.ErrRecordNotFound menjadi ErrBoardNotFound.
+func (s *boardService) findBoard(publicID uuid.UUID) (*models.Board, error) {
+	board, err := s.boardRepo.FindByPublicID(publicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	return board, nil
+}
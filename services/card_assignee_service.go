@@ -0,0 +1,171 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// workloadDateLayout adalah format tanggal untuk pengelompokan due date di workload.
+const workloadDateLayout = "2006-01-02"
+
+// WorkloadBoard adalah kartu-kartu milik user di satu board, dikelompokkan per due date.
+type WorkloadBoard struct {
+	BoardPublicID uuid.UUID          `json:"board_id"`
+	BoardTitle    string             `json:"board_title"`
+	DueGroups     []WorkloadDueGroup `json:"due_groups"`
+}
+
+// WorkloadDueGroup adalah kartu-kartu dengan due date di hari yang sama (format YYYY-MM-DD, UTC).
+// DueDate nil berarti kelompok kartu tanpa due date.
+type WorkloadDueGroup struct {
+	DueDate *string               `json:"due_date"`
+	Cards   []models.AssignedCard `json:"cards"`
+}
+
+// CardAssigneeService berisi aturan bisnis penugasan user ke kartu.
+type CardAssigneeService interface {
+	ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.CardAssigneeDetail, error)
+	Assign(principal *utils.Principal, cardPublicID, userPublicID uuid.UUID) error
+	Unassign(principal *utils.Principal, cardPublicID, userPublicID uuid.UUID) error
+	Workload(principal *utils.Principal, userPublicID uuid.UUID) ([]WorkloadBoard, error)
+}
+
+type cardAssigneeService struct {
+	assigneeRepo repositories.CardAssigneeRepository
+	memberRepo   repositories.BoardMemberRepository
+	userRepo     repositories.UserRepository
+	cardService  CardService
+}
+
+// NewCardAssigneeService membuat CardAssigneeService dengan dependency yang dibutuhkan.
+func NewCardAssigneeService(
+	assigneeRepo repositories.CardAssigneeRepository,
+	memberRepo repositories.BoardMemberRepository,
+	userRepo repositories.UserRepository,
+	cardService CardService,
+) CardAssigneeService {
+	return &cardAssigneeService{
+		assigneeRepo: assigneeRepo,
+		memberRepo:   memberRepo,
+		userRepo:     userRepo,
+		cardService:  cardService,
+	}
+}
+
+// ListByCard mengambil daftar assignee kartu. Cukup permission PermBoardView.
+func (s *cardAssigneeService) ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.CardAssigneeDetail, error) {
+	card, _, err := s.cardService.GetAuthorized(principal, cardPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+	return s.assigneeRepo.ListByCard(card.InternalId)
+}
+
+// Assign menugaskan user ke kartu. Butuh permission PermCardWrite,
+// dan user yang ditugaskan WAJIB member board kartu tersebut.
+func (s *cardAssigneeService) Assign(principal *utils.Principal, cardPublicID, userPublicID uuid.UUID) error {
+	card, list, err := s.cardService.GetAuthorized(principal, cardPublicID, PermCardWrite)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.findUser(userPublicID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.memberRepo.Find(list.BoardInternalID, user.InternalID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAssigneeNotMember
+		}
+		return err
+	}
+
+	return s.assigneeRepo.Assign(card.InternalId, user.InternalID)
+}
+
+// Unassign melepas user dari kartu. Butuh permission PermCardWrite.
+func (s *cardAssigneeService) Unassign(principal *utils.Principal, cardPublicID, userPublicID uuid.UUID) error {
+	card, _, err := s.cardService.GetAuthorized(principal, cardPublicID, PermCardWrite)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.findUser(userPublicID)
+	if err != nil {
+		return err
+	}
+	return s.assigneeRepo.Unassign(card.InternalId, user.InternalID)
+}
+
+// Workload mengambil semua kartu yang ditugaskan ke user, dikelompokkan per board lalu per due date.
+// Hanya user itu sendiri atau admin global yang boleh melihat workload seseorang.
+func (s *cardAssigneeService) Workload(principal *utils.Principal, userPublicID uuid.UUID) ([]WorkloadBoard, error) {
+	if principal.PublicID != userPublicID && !principal.IsAdmin() {
+		return nil, ErrForbidden
+	}
+
+	user, err := s.findUser(userPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.assigneeRepo.FindAssignedCards(user.InternalID)
+	if err != nil {
+		return nil, err
+	}
+	return groupWorkload(cards), nil
+}
+
+func (s *cardAssigneeService) findUser(publicID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.FindByPublicID(publicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// groupWorkload mengelompokkan kartu per board lalu per hari due date.
+// cards diasumsikan sudah terurut per board dan due date (lihat FindAssignedCards),
+// jadi cukup membandingkan dengan kelompok terakhir.
+func groupWorkload(cards []models.AssignedCard) []WorkloadBoard {
+	boards := []WorkloadBoard{}
+	for _, card := range cards {
+		if len(boards) == 0 || boards[len(boards)-1].BoardPublicID != card.BoardPublicID {
+			boards = append(boards, WorkloadBoard{
+				BoardPublicID: card.BoardPublicID,
+				BoardTitle:    card.BoardTitle,
+			})
+		}
+		board := &boards[len(boards)-1]
+
+		var dueDate *string
+		if card.DueDate != nil {
+			day := card.DueDate.UTC().Format(workloadDateLayout)
+			dueDate = &day
+		}
+
+		groups := board.DueGroups
+		if len(groups) == 0 || !sameDueDate(groups[len(groups)-1].DueDate, dueDate) {
+			board.DueGroups = append(board.DueGroups, WorkloadDueGroup{DueDate: dueDate})
+		}
+		group := &board.DueGroups[len(board.DueGroups)-1]
+		group.Cards = append(group.Cards, card)
+	}
+	return boards
+}
+
+func sameDueDate(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+)
+
+func TestGroupWorkload(t *testing.T) {
+	boardA, boardB := uuid.New(), uuid.New()
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	day := func(d, hour int, loc *time.Location) *time.Time {
+		due := time.Date(2025, time.March, d, hour, 0, 0, 0, loc)
+		return &due
+	}
+
+	// Sudah terurut per board lalu due date, seperti hasil FindAssignedCards.
+	cards := []models.AssignedCard{
+		{Title: "a1", BoardPublicID: boardA, BoardTitle: "A", DueDate: day(1, 9, time.UTC)},
+		{Title: "a2", BoardPublicID: boardA, BoardTitle: "A", DueDate: day(1, 23, time.UTC)},
+		// 2 Maret 05:00 WIB = 1 Maret 22:00 UTC: masuk kelompok 1 Maret.
+		{Title: "a3", BoardPublicID: boardA, BoardTitle: "A", DueDate: day(2, 5, jakarta)},
+		{Title: "a4", BoardPublicID: boardA, BoardTitle: "A", DueDate: day(3, 9, time.UTC)},
+		{Title: "a5", BoardPublicID: boardA, BoardTitle: "A"},
+		{Title: "b1", BoardPublicID: boardB, BoardTitle: "B"},
+		{Title: "b2", BoardPublicID: boardB, BoardTitle: "B"},
+	}
+
+	type group struct {
+		dueDate string // "" berarti tanpa due date
+		titles  []string
+	}
+	want := []struct {
+		boardID uuid.UUID
+		title   string
+		groups  []group
+	}{
+		{boardA, "A", []group{
+			{"2025-03-01", []string{"a1", "a2", "a3"}},
+			{"2025-03-03", []string{"a4"}},
+			{"", []string{"a5"}},
+		}},
+		{boardB, "B", []group{
+			{"", []string{"b1", "b2"}},
+		}},
+	}
+
+	got := groupWorkload(cards)
+	if len(got) != len(want) {
+		t.Fatalf("got %d boards, want %d", len(got), len(want))
+	}
+	for i, board := range got {
+		if board.BoardPublicID != want[i].boardID || board.BoardTitle != want[i].title {
+			t.Errorf("board[%d] = %s (%s), want %s (%s)", i, board.BoardTitle, board.BoardPublicID, want[i].title, want[i].boardID)
+		}
+		if len(board.DueGroups) != len(want[i].groups) {
+			t.Fatalf("board %s has %d due groups, want %d", board.BoardTitle, len(board.DueGroups), len(want[i].groups))
+		}
+		for j, dueGroup := range board.DueGroups {
+			wantGroup := want[i].groups[j]
+			var dueDate string
+			if dueGroup.DueDate != nil {
+				dueDate = *dueGroup.DueDate
+			}
+			if dueDate != wantGroup.dueDate {
+				t.Errorf("board %s group[%d] due date = %q, want %q", board.BoardTitle, j, dueDate, wantGroup.dueDate)
+			}
+
+			var titles []string
+			for _, card := range dueGroup.Cards {
+				titles = append(titles, card.Title)
+			}
+			if len(titles) != len(wantGroup.titles) {
+				t.Errorf("board %s group[%d] cards = %q, want %q", board.BoardTitle, j, titles, wantGroup.titles)
+				continue
+			}
+			for k := range titles {
+				if titles[k] != wantGroup.titles[k] {
+					t.Errorf("board %s group[%d] cards = %q, want %q", board.BoardTitle, j, titles, wantGroup.titles)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestGroupWorkloadEmpty(t *testing.T) {
+	got := groupWorkload(nil)
+	if got == nil || len(got) != 0 {
+		t.Errorf("groupWorkload(nil) = %#v, want an empty non-nil slice", got)
+	}
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/storage"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// sniffLength adalah jumlah byte awal file yang dibaca untuk mendeteksi content type
+// (sama dengan batas yang dipakai http.DetectContentType).
+const sniffLength = 512
+
+// maxFileNameLength sesuai dengan varchar(255) di tabel card_attachments.
+const maxFileNameLength = 255
+
+// UploadAttachmentInput adalah file yang di-upload ke kartu.
+// Content dibaca secara streaming; Size adalah ukuran yang dilaporkan multipart form.
+type UploadAttachmentInput struct {
+	FileName string
+	Size     int64
+	Content  io.Reader
+}
+
+// AttachmentDownload adalah file yang siap di-stream ke client. Pemanggil WAJIB menutup Body.
+type AttachmentDownload struct {
+	Attachment  *models.CardAttachment
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+// CardAttachmentService berisi aturan bisnis upload, download, dan hapus attachment kartu.
+type CardAttachmentService interface {
+	Upload(principal *utils.Principal, cardPublicID uuid.UUID, input UploadAttachmentInput) (*models.CardAttachment, error)
+	ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.CardAttachment, error)
+	Download(principal *utils.Principal, attachmentPublicID uuid.UUID) (*AttachmentDownload, error)
+	Thumbnail(principal *utils.Principal, attachmentPublicID uuid.UUID) (*AttachmentDownload, error)
+	Delete(principal *utils.Principal, attachmentPublicID uuid.UUID) error
+}
+
+type cardAttachmentService struct {
+	attachmentRepo repositories.CardAttachmentRepository
+	cardService    CardService
+	storage        storage.Storage
+	thumbnails     ThumbnailWorker
+	maxSize        int64
+}
+
+// NewCardAttachmentService membuat CardAttachmentService.
+// maxSize adalah ukuran file maksimal dalam byte (config MAX_UPLOAD_SIZE).
+func NewCardAttachmentService(
+	attachmentRepo repositories.CardAttachmentRepository,
+	cardService CardService,
+	store storage.Storage,
+	thumbnails ThumbnailWorker,
+	maxSize int64,
+) CardAttachmentService {
+	return &cardAttachmentService{
+		attachmentRepo: attachmentRepo,
+		cardService:    cardService,
+		storage:        store,
+		thumbnails:     thumbnails,
+		maxSize:        maxSize,
+	}
+}
+
+// Upload menyimpan file ke storage lalu mencatat metadata-nya. Butuh permission PermCardWrite.
+//
+// Content type dideteksi dari isi file (magic bytes), bukan dari header Content-Type
+// yang dikirim client, karena header tersebut bisa dipalsukan. Thumbnail gambar dibuat
+// di background oleh ThumbnailWorker, sehingga upload tidak perlu menunggu.
+func (s *cardAttachmentService) Upload(principal *utils.Principal, cardPublicID uuid.UUID, input UploadAttachmentInput) (*models.CardAttachment, error) {
+	card, _, err := s.cardService.GetAuthorized(principal, cardPublicID, PermCardWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Size <= 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidInput)
+	}
+	if input.Size > s.maxSize {
+		return nil, fmt.Errorf("%w: file must be at most %d bytes", ErrFileTooLarge, s.maxSize)
+	}
+
+	fileName := sanitizeFileName(input.FileName)
+	if fileName == "" {
+		return nil, fmt.Errorf("%w: file name is required", ErrInvalidInput)
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(input.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType := detectContentType(head, fileName)
+
+	attachment := &models.CardAttachment{
+		PublicID:     uuid.New(),
+		FileName:     fileName,
+		ContentType:  contentType,
+		Size:         input.Size,
+		UserID:       principal.InternalID,
+		UserPublicID: principal.PublicID,
+		CardID:       card.InternalId,
+		CardPublicID: card.PublicId,
+	}
+	attachment.File = attachmentKey(card.PublicId, attachment.PublicID)
+	if utils.IsThumbnailable(contentType) {
+		attachment.ThumbnailStatus = models.ThumbnailStatusPending
+	}
+
+	// Byte yang sudah dibaca untuk deteksi digabung lagi dengan sisa isi file.
+	// LimitReader menjaga agar yang tersimpan tidak melebihi ukuran yang dilaporkan.
+	content := io.LimitReader(io.MultiReader(bytes.NewReader(head), input.Content), input.Size)
+
+	ctx := context.Background()
+	if err := s.storage.Put(ctx, attachment.File, content, input.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		// Metadata gagal disimpan: hapus file agar tidak jadi sampah di storage.
+		if delErr := s.storage.Delete(ctx, attachment.File); delErr != nil {
+			log.Println("Failed to clean up attachment object:", delErr)
+		}
+		return nil, err
+	}
+
+	if attachment.ThumbnailStatus == models.ThumbnailStatusPending {
+		s.thumbnails.Notify()
+	}
+	return attachment, nil
+}
+
+// ListByCard mengambil metadata semua attachment kartu. Cukup permission PermBoardView.
+func (s *cardAttachmentService) ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.CardAttachment, error) {
+	card, _, err := s.cardService.GetAuthorized(principal, cardPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.FindByCard(card.InternalId)
+}
+
+// Download membuka file attachment untuk di-stream. Cukup permission PermBoardView.
+func (s *cardAttachmentService) Download(principal *utils.Principal, attachmentPublicID uuid.UUID) (*AttachmentDownload, error) {
+	attachment, err := s.getAuthorized(principal, attachmentPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := s.storage.Get(context.Background(), attachment.File)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return &AttachmentDownload{Attachment: attachment, Body: object.Body, Size: object.Size, ContentType: attachment.ContentType}, nil
+}
+
+// Thumbnail membuka thumbnail attachment gambar. Cukup permission PermBoardView.
+// Mengembalikan ErrAttachmentNotFound jika thumbnail belum (atau tidak bisa) dibuat.
+func (s *cardAttachmentService) Thumbnail(principal *utils.Principal, attachmentPublicID uuid.UUID) (*AttachmentDownload, error) {
+	attachment, err := s.getAuthorized(principal, attachmentPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.ThumbnailStatus != models.ThumbnailStatusReady {
+		return nil, ErrAttachmentNotFound
+	}
+
+	object, err := s.storage.Get(context.Background(), attachment.ThumbnailKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return &AttachmentDownload{Attachment: attachment, Body: object.Body, Size: object.Size, ContentType: utils.ThumbnailContentType}, nil
+}
+
+// Delete menghapus attachment. Butuh permission PermCardWrite.
+// Metadata dihapus dulu: jika penghapusan file gagal, yang tersisa hanya file yatim
+// di storage, bukan metadata yang menunjuk ke file yang sudah tidak ada.
+func (s *cardAttachmentService) Delete(principal *utils.Principal, attachmentPublicID uuid.UUID) error {
+	attachment, err := s.getAuthorized(principal, attachmentPublicID, PermCardWrite)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.Delete(attachment); err != nil {
+		return err
+	}
+	deleteAttachmentObjects(s.storage, []models.CardAttachment{*attachment})
+	return nil
+}
+
+// deleteAttachmentObjects menghapus file dan thumbnail attachments dari storage.
+// Dipanggil SETELAH metadata-nya terhapus (langsung, atau ikut terhapus lewat ON DELETE CASCADE
+// saat kartu, list, atau board dihapus). Kegagalan hanya dicatat di log: yang tersisa
+// hanya file yatim di storage, dan penghapusan yang memicunya sudah berhasil.
+func deleteAttachmentObjects(store storage.Storage, attachments []models.CardAttachment) {
+	for _, attachment := range attachments {
+		for _, key := range []string{attachment.File, attachment.ThumbnailKey} {
+			if key == "" {
+				continue
+			}
+			if err := store.Delete(context.Background(), key); err != nil {
+				log.Println("Failed to delete attachment object:", err)
+			}
+		}
+	}
+}
+
+// getAuthorized mengambil attachment lalu mengecek permission lewat kartunya.
+// Non-member mendapat ErrAttachmentNotFound agar keberadaan attachment tidak bocor.
+func (s *cardAttachmentService) getAuthorized(principal *utils.Principal, attachmentPublicID uuid.UUID, permission Permission) (*models.CardAttachment, error) {
+	attachment, err := s.attachmentRepo.FindByPublicID(attachmentPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	if _, _, err := s.cardService.GetAuthorized(principal, attachment.CardPublicID, permission); err != nil {
+		if errors.Is(err, ErrCardNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// attachmentKey membuat key storage untuk attachment. Nama file asli sengaja tidak dipakai
+// agar key selalu aman (tanpa spasi, "../", atau karakter aneh).
+func attachmentKey(cardID, attachmentID uuid.UUID) string {
+	return fmt.Sprintf("cards/%s/%s", cardID, attachmentID)
+}
+
+// detectContentType mendeteksi MIME type dari isi file. Jika isi file tidak dikenali
+// (misal file teks biasa), ekstensi nama file dipakai sebagai petunjuk tambahan.
+func detectContentType(head []byte, fileName string) string {
+	contentType := http.DetectContentType(head)
+	if contentType != "application/octet-stream" && !strings.HasPrefix(contentType, "text/plain") {
+		return contentType
+	}
+
+	// Ekstensi hanya dipercaya untuk tipe yang tidak bisa dieksekusi browser.
+	byExtension := mime.TypeByExtension(strings.ToLower(filepath.Ext(fileName)))
+	if byExtension != "" && !strings.Contains(byExtension, "html") &&
+		!strings.Contains(byExtension, "javascript") && !strings.Contains(byExtension, "svg") {
+		return byExtension
+	}
+	return contentType
+}
+
+// sanitizeFileName mengambil nama file saja (tanpa folder) dan membuang karakter kontrol,
+// karena nilainya akan dikirim lagi di header Content-Disposition.
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	if len(name) > maxFileNameLength {
+		ext := filepath.Ext(name)
+		if len(ext) > 20 {
+			ext = ""
+		}
+		// Batasnya dalam byte, jadi potongan dimundurkan ke awal rune agar karakter
+		// multi-byte (misal huruf non-Latin atau emoji) tidak terbelah menjadi UTF-8 rusak.
+		cut := maxFileNameLength - len(ext)
+		for cut > 0 && !utf8.RuneStart(name[cut]) {
+			cut--
+		}
+		name = name[:cut] + ext
+	}
+	return name
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain name", "report.pdf", "report.pdf"},
+		{"strips unix folders", "../../etc/passwd", "passwd"},
+		{"strips windows folders", `C:\Users\me\photo.png`, "photo.png"},
+		{"removes control characters and quotes", "a\x00b\"c\r\n.txt", "abc.txt"},
+		{"trims spaces", "  notes.md  ", "notes.md"},
+		{"empty", "", ""},
+		{"only dot", ".", ""},
+		{"only slash", "/", ""},
+		{"keeps unicode", "laporan-ñandú-日本.pdf", "laporan-ñandú-日本.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeFileName(tt.in); got != tt.want {
+				t.Errorf("sanitizeFileName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeFileNameTruncatesOnRuneBoundary(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		ext  string
+	}{
+		{"ascii keeps extension", strings.Repeat("a", 300) + ".pdf", ".pdf"},
+		// "日" 3 byte: 251 byte tersisa untuk nama tidak habis dibagi 3.
+		{"three byte runes", strings.Repeat("日", 100) + ".pdf", ".pdf"},
+		// Emoji 4 byte, dengan offset 1 byte agar batas potong jatuh di tengah rune.
+		{"four byte runes", "x" + strings.Repeat("😀", 80) + ".txt", ".txt"},
+		{"long extension is dropped", strings.Repeat("é", 200) + "." + strings.Repeat("x", 30), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeFileName(tt.in)
+			if len(got) > maxFileNameLength {
+				t.Errorf("len = %d, want at most %d", len(got), maxFileNameLength)
+			}
+			if len(got) < maxFileNameLength-4 {
+				t.Errorf("len = %d, truncated more than one rune too far", len(got))
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("sanitizeFileName returned invalid UTF-8: %q", got)
+			}
+			if !strings.HasSuffix(got, tt.ext) {
+				t.Errorf("sanitizeFileName = %q, want suffix %q", got, tt.ext)
+			}
+		})
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name     string
+		head     []byte
+		fileName string
+		want     string
+	}{
+		{"sniffed type wins over extension", png, "photo.txt", "image/png"},
+		{"plain text uses extension", []byte(`{"a": 1}`), "data.json", "application/json"},
+		{"unknown binary uses extension", []byte{0x00, 0x01, 0x02}, "archive.pdf", "application/pdf"},
+		{"html extension is not trusted", []byte("just text"), "page.html", "text/plain; charset=utf-8"},
+		{"svg extension is not trusted", []byte("just text"), "icon.svg", "text/plain; charset=utf-8"},
+		{"javascript extension is not trusted", []byte{0x00, 0x01}, "app.js", "application/octet-stream"},
+		{"no extension", []byte{0x00, 0x01}, "blob", "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectContentType(tt.head, tt.fileName); got != tt.want {
+				t.Errorf("detectContentType(%q) = %q, want %q", tt.fileName, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/storage"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// CreateCardInput adalah data untuk membuat kartu di sebuah list.
+// Position opsional: index tujuan (0 = paling atas). Jika kosong, kartu ditaruh paling bawah.
+type CreateCardInput struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	DueDate     *time.Time `json:"due_date"`
+	StartDate   *time.Time `json:"start_date"`
+	Priority    string     `json:"priority"`
+	Estimate    *int       `json:"estimate"`
+	Position    *int       `json:"position"`
+}
+
+// UpdateCardInput adalah data untuk mengubah isi kartu. Field nil berarti "tidak diubah".
+// Field Clear* dipakai untuk mengosongkan field opsional (due date, start date, estimate, cover).
+// Priority diisi string kosong untuk menghapus prioritas.
+type UpdateCardInput struct {
+	Title          *string    `json:"title"`
+	Description    *string    `json:"description"`
+	DueDate        *time.Time `json:"due_date"`
+	ClearDueDate   bool       `json:"clear_due_date"`
+	StartDate      *time.Time `json:"start_date"`
+	ClearStartDate bool       `json:"clear_start_date"`
+	DueComplete    *bool      `json:"due_complete"`
+	Priority       *string    `json:"priority"`
+	Estimate       *int       `json:"estimate"`
+	ClearEstimate  bool       `json:"clear_estimate"`
+
+	// CoverAttachmentID adalah PublicID attachment gambar milik kartu ini.
+	CoverAttachmentID *uuid.UUID `json:"cover_attachment_id"`
+	ClearCover        bool       `json:"clear_cover"`
+}
+
+// maxCardEstimate membatasi nilai estimate agar salah ketik (misal 10000) langsung ketahuan.
+const maxCardEstimate = 1000
+
+// cardPriorities adalah nilai Priority yang valid (string kosong = tanpa prioritas).
+var cardPriorities = map[string]bool{
+	"":                        true,
+	models.CardPriorityLow:    true,
+	models.CardPriorityMedium: true,
+	models.CardPriorityHigh:   true,
+	models.CardPriorityUrgent: true,
+}
+
+// MoveCardInput adalah data untuk memindahkan kartu.
+// ListID adalah PublicID list tujuan (boleh sama dengan list asal untuk sekadar mengubah urutan).
+type MoveCardInput struct {
+	ListID   uuid.UUID `json:"list_id"`
+	Position int       `json:"position"`
+}
+
+// CardFilter adalah filter untuk mengambil kartu di seluruh board.
+// LabelIDs kosong berarti tanpa filter label. MatchAll true berarti kartu harus punya
+// SEMUA label; false berarti cukup salah satu.
+type CardFilter struct {
+	LabelIDs []uuid.UUID
+	MatchAll bool
+}
+
+// CardService berisi aturan bisnis untuk kartu di dalam list.
+type CardService interface {
+	Create(principal *utils.Principal, listPublicID uuid.UUID, input CreateCardInput) (*models.Card, error)
+	ListByList(principal *utils.Principal, listPublicID uuid.UUID) ([]models.Card, error)
+	ListByBoard(principal *utils.Principal, boardPublicID uuid.UUID, filter CardFilter) ([]models.Card, error)
+	Get(principal *utils.Principal, cardPublicID uuid.UUID) (*models.Card, error)
+	Update(principal *utils.Principal, cardPublicID uuid.UUID, input UpdateCardInput) (*models.Card, error)
+	Delete(principal *utils.Principal, cardPublicID uuid.UUID) error
+	Move(principal *utils.Principal, cardPublicID uuid.UUID, input MoveCardInput) (*models.Card, error)
+	GetAuthorized(principal *utils.Principal, cardPublicID uuid.UUID, permission Permission) (*models.Card, *models.List, error)
+}
+
+type cardService struct {
+	cardRepo       repositories.CardRepository
+	listRepo       repositories.ListRepository
+	labelRepo      repositories.LabelRepository
+	attachmentRepo repositories.CardAttachmentRepository
+	storage        storage.Storage
+	boardService   BoardService
+	listService    ListService
+	authz          AuthorizationService
+}
+
+// NewCardService membuat CardService dengan dependency yang dibutuhkan.
+func NewCardService(
+	cardRepo repositories.CardRepository,
+	listRepo repositories.ListRepository,
+	labelRepo repositories.LabelRepository,
+	attachmentRepo repositories.CardAttachmentRepository,
+	store storage.Storage,
+	boardService BoardService,
+	listService ListService,
+	authz AuthorizationService,
+) CardService {
+	return &cardService{
+		cardRepo:       cardRepo,
+		listRepo:       listRepo,
+		labelRepo:      labelRepo,
+		attachmentRepo: attachmentRepo,
+		storage:        store,
+		boardService:   boardService,
+		listService:    listService,
+		authz:          authz,
+	}
+}
+
+// Create membuat kartu baru di list. Butuh permission PermCardWrite.
+func (s *cardService) Create(principal *utils.Principal, listPublicID uuid.UUID, input CreateCardInput) (*models.Card, error) {
+	list, err := s.listService.GetAuthorized(principal, listPublicID, PermCardWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+
+	position := -1
+	if input.Position != nil {
+		if *input.Position < 0 {
+			return nil, fmt.Errorf("%w: position cannot be negative", ErrInvalidInput)
+		}
+		position = *input.Position
+	}
+
+	card := &models.Card{
+		ListID:       list.InternalID,
+		ListPublicID: list.PublicID,
+		Title:        title,
+		Description:  strings.TrimSpace(input.Description),
+		DueDate:      input.DueDate,
+		StartDate:    input.StartDate,
+		Priority:     input.Priority,
+		Estimate:     input.Estimate,
+	}
+	if err := validateCardMetadata(card); err != nil {
+		return nil, err
+	}
+	if err := s.cardRepo.Create(card, position); err != nil {
+		return nil, err
+	}
+	card.FillDueStatus(time.Now())
+	return card, nil
+}
+
+// ListByList mengambil semua kartu di list sesuai urutan CardOrder.
+func (s *cardService) ListByList(principal *utils.Principal, listPublicID uuid.UUID) ([]models.Card, error) {
+	list, err := s.listService.GetAuthorized(principal, listPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+	return s.cardRepo.FindByListOrdered(list.InternalID)
+}
+
+// ListByBoard mengambil kartu di seluruh list board, opsional difilter berdasarkan label.
+// Label yang bukan milik board dianggap tidak ada (ErrLabelNotFound).
+func (s *cardService) ListByBoard(principal *utils.Principal, boardPublicID uuid.UUID, filter CardFilter) ([]models.Card, error) {
+	board, err := s.boardService.GetByPublicID(principal, boardPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	var labelIDs []int64
+	if len(filter.LabelIDs) > 0 {
+		labels, err := s.labelRepo.FindByPublicIDs(board.InternalID, filter.LabelIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(labels) != len(uniqueUUIDs(filter.LabelIDs)) {
+			return nil, ErrLabelNotFound
+		}
+		for _, label := range labels {
+			labelIDs = append(labelIDs, label.InternalID)
+		}
+	}
+
+	return s.cardRepo.FindByBoard(board.InternalID, labelIDs, filter.MatchAll)
+}
+
+// Get mengambil detail satu kartu. Cukup permission PermBoardView.
+func (s *cardService) Get(principal *utils.Principal, cardPublicID uuid.UUID) (*models.Card, error) {
+	card, _, err := s.GetAuthorized(principal, cardPublicID, PermBoardView)
+	return card, err
+}
+
+// Update mengubah isi dan metadata kartu (tanggal, status selesai, prioritas, estimate, cover).
+// Butuh permission PermCardWrite.
+func (s *cardService) Update(principal *utils.Principal, cardPublicID uuid.UUID, input UpdateCardInput) (*models.Card, error) {
+	card, _, err := s.GetAuthorized(principal, cardPublicID, PermCardWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		title := strings.TrimSpace(*input.Title)
+		if title == "" {
+			return nil, fmt.Errorf("%w: title cannot be empty", ErrInvalidInput)
+		}
+		card.Title = title
+	}
+	if input.Description != nil {
+		card.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.ClearDueDate {
+		card.DueDate = nil
+	} else if input.DueDate != nil {
+		card.DueDate = input.DueDate
+	}
+	if input.ClearStartDate {
+		card.StartDate = nil
+	} else if input.StartDate != nil {
+		card.StartDate = input.StartDate
+	}
+	if input.Priority != nil {
+		card.Priority = *input.Priority
+	}
+	if input.ClearEstimate {
+		card.Estimate = nil
+	} else if input.Estimate != nil {
+		card.Estimate = input.Estimate
+	}
+
+	// CompletedAt hanya berubah saat status selesai benar-benar berubah,
+	// agar mengirim due_complete=true dua kali tidak menggeser waktu selesainya.
+	if input.DueComplete != nil && *input.DueComplete != card.DueComplete {
+		card.DueComplete = *input.DueComplete
+		card.CompletedAt = nil
+		if card.DueComplete {
+			now := time.Now()
+			card.CompletedAt = &now
+		}
+	}
+
+	if input.ClearCover {
+		card.CoverAttachmentID, card.CoverAttachmentPublicID = nil, nil
+	} else if input.CoverAttachmentID != nil {
+		if err := s.setCover(card, *input.CoverAttachmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateCardMetadata(card); err != nil {
+		return nil, err
+	}
+	if err := s.cardRepo.Update(card); err != nil {
+		return nil, err
+	}
+	card.FillDueStatus(time.Now())
+	return card, nil
+}
+
+// Delete menghapus kartu. Butuh permission PermCardWrite.
+// Metadata attachment ikut terhapus lewat ON DELETE CASCADE; file-nya dihapus dari storage
+// setelah kartu berhasil dihapus.
+func (s *cardService) Delete(principal *utils.Principal, cardPublicID uuid.UUID) error {
+	card, _, err := s.GetAuthorized(principal, cardPublicID, PermCardWrite)
+	if err != nil {
+		return err
+	}
+	attachments, err := s.attachmentRepo.FindByCard(card.InternalId)
+	if err != nil {
+		return err
+	}
+	if err := s.cardRepo.Delete(card); err != nil {
+		if errors.Is(err, repositories.ErrCardMovedConcurrently) {
+			return ErrCardMovedConcurrently
+		}
+		return err
+	}
+	deleteAttachmentObjects(s.storage, attachments)
+	return nil
+}
+
+// Move memindahkan kartu ke list lain (atau ke index lain di list yang sama).
+// List tujuan harus berada di board yang sama dengan list asal.
+func (s *cardService) Move(principal *utils.Principal, cardPublicID uuid.UUID, input MoveCardInput) (*models.Card, error) {
+	card, source, err := s.GetAuthorized(principal, cardPublicID, PermCardWrite)
+	if err != nil {
+		return nil, err
+	}
+	if input.Position < 0 {
+		return nil, fmt.Errorf("%w: position cannot be negative", ErrInvalidInput)
+	}
+
+	target := source
+	if input.ListID != uuid.Nil && input.ListID != source.PublicID {
+		target, err = s.listRepo.FindByPublicID(input.ListID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrListNotFound
+			}
+			return nil, err
+		}
+		// Memindahkan kartu antar board tidak didukung: hak akses dan label
+		// kartu terikat ke board asalnya.
+		if target.BoardInternalID != source.BoardInternalID {
+			return nil, fmt.Errorf("%w: target list must be on the same board", ErrInvalidInput)
+		}
+	}
+
+	if err := s.cardRepo.Move(card, target, input.Position); err != nil {
+		if errors.Is(err, repositories.ErrCardMovedConcurrently) {
+			return nil, ErrCardMovedConcurrently
+		}
+		return nil, err
+	}
+	return card, nil
+}
+
+// GetAuthorized mengambil kartu beserta list-nya, lalu memastikan principal punya permission
+// di board pemilik kartu. Non-member mendapat ErrCardNotFound agar keberadaan kartu tidak bocor.
+func (s *cardService) GetAuthorized(principal *utils.Principal, cardPublicID uuid.UUID, permission Permission) (*models.Card, *models.List, error) {
+	card, err := s.cardRepo.FindByPublicID(cardPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCardNotFound
+		}
+		return nil, nil, err
+	}
+
+	list, err := s.listRepo.FindByID(card.ListID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCardNotFound
+		}
+		return nil, nil, err
+	}
+
+	if _, err := s.authz.Authorize(principal, list.BoardInternalID, permission); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return nil, nil, ErrCardNotFound
+		}
+		return nil, nil, err
+	}
+	return card, list, nil
+}
+
+// setCover menjadikan attachment sebagai cover kartu. Attachment harus milik kartu yang sama
+// dan berupa gambar; attachment kartu lain dianggap tidak ada (ErrAttachmentNotFound).
+func (s *cardService) setCover(card *models.Card, attachmentPublicID uuid.UUID) error {
+	attachment, err := s.attachmentRepo.FindByPublicID(attachmentPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	}
+	if attachment.CardID != card.InternalId {
+		return ErrAttachmentNotFound
+	}
+	if !utils.IsThumbnailable(attachment.ContentType) {
+		return fmt.Errorf("%w: cover must be an image attachment", ErrInvalidInput)
+	}
+
+	card.CoverAttachmentID, card.CoverAttachmentPublicID = &attachment.InternalID, &attachment.PublicID
+	return nil
+}
+
+// validateCardMetadata memvalidasi field metadata kartu setelah perubahan diterapkan,
+// sehingga aturan antar-field (start date <= due date) dicek dengan nilai akhirnya.
+func validateCardMetadata(card *models.Card) error {
+	if !cardPriorities[card.Priority] {
+		return fmt.Errorf("%w: priority must be one of low, medium, high, urgent", ErrInvalidInput)
+	}
+	if card.Estimate != nil && (*card.Estimate < 0 || *card.Estimate > maxCardEstimate) {
+		return fmt.Errorf("%w: estimate must be between 0 and %d", ErrInvalidInput, maxCardEstimate)
+	}
+	if card.StartDate != nil && card.DueDate != nil && card.StartDate.After(*card.DueDate) {
+		return fmt.Errorf("%w: start date cannot be after due date", ErrInvalidInput)
+	}
+	return nil
+}
+
+// uniqueUUIDs membuang UUID duplikat (misal "?labels=a,a") dengan urutan tetap.
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
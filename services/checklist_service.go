@@ -0,0 +1,344 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// maxChecklistTitleLength sesuai dengan varchar(255) di tabel checklists dan checklist_items.
+const maxChecklistTitleLength = 255
+
+// CreateChecklistInput adalah data untuk membuat checklist di kartu.
+// Position opsional: index tujuan (0 = paling atas). Jika kosong, checklist ditaruh paling bawah.
+type CreateChecklistInput struct {
+	Title    string `json:"title"`
+	Position *int   `json:"position"`
+}
+
+// UpdateChecklistInput adalah data untuk mengubah checklist. Field nil berarti "tidak diubah".
+type UpdateChecklistInput struct {
+	Title    *string `json:"title"`
+	Position *int    `json:"position"`
+}
+
+// CreateChecklistItemInput adalah data untuk menambah item ke checklist.
+// AssigneeID (PublicID user) dan DueDate opsional.
+type CreateChecklistItemInput struct {
+	Title      string     `json:"title"`
+	Position   *int       `json:"position"`
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+	DueDate    *time.Time `json:"due_date"`
+}
+
+// UpdateChecklistItemInput adalah data untuk mengubah item. Field nil berarti "tidak diubah".
+// ClearAssignee dan ClearDueDate dipakai untuk mengosongkan assignee dan due date.
+type UpdateChecklistItemInput struct {
+	Title         *string    `json:"title"`
+	Checked       *bool      `json:"checked"`
+	Position      *int       `json:"position"`
+	AssigneeID    *uuid.UUID `json:"assignee_id"`
+	ClearAssignee bool       `json:"clear_assignee"`
+	DueDate       *time.Time `json:"due_date"`
+	ClearDueDate  bool       `json:"clear_due_date"`
+}
+
+// ChecklistService berisi aturan bisnis checklist dan item checklist di kartu.
+type ChecklistService interface {
+	Create(principal *utils.Principal, cardPublicID uuid.UUID, input CreateChecklistInput) (*models.Checklist, error)
+	ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.Checklist, error)
+	Update(principal *utils.Principal, checklistPublicID uuid.UUID, input UpdateChecklistInput) (*models.Checklist, error)
+	Delete(principal *utils.Principal, checklistPublicID uuid.UUID) error
+
+	CreateItem(principal *utils.Principal, checklistPublicID uuid.UUID, input CreateChecklistItemInput) (*models.ChecklistItem, error)
+	UpdateItem(principal *utils.Principal, itemPublicID uuid.UUID, input UpdateChecklistItemInput) (*models.ChecklistItem, error)
+	DeleteItem(principal *utils.Principal, itemPublicID uuid.UUID) error
+}
+
+type checklistService struct {
+	checklistRepo repositories.ChecklistRepository
+	memberRepo    repositories.BoardMemberRepository
+	userRepo      repositories.UserRepository
+	cardService   CardService
+}
+
+// NewChecklistService membuat ChecklistService dengan dependency yang dibutuhkan.
+func NewChecklistService(
+	checklistRepo repositories.ChecklistRepository,
+	memberRepo repositories.BoardMemberRepository,
+	userRepo repositories.UserRepository,
+	cardService CardService,
+) ChecklistService {
+	return &checklistService{
+		checklistRepo: checklistRepo,
+		memberRepo:    memberRepo,
+		userRepo:      userRepo,
+		cardService:   cardService,
+	}
+}
+
+// Create membuat checklist baru di kartu. Butuh permission PermCardWrite.
+func (s *checklistService) Create(principal *utils.Principal, cardPublicID uuid.UUID, input CreateChecklistInput) (*models.Checklist, error) {
+	card, _, err := s.cardService.GetAuthorized(principal, cardPublicID, PermCardWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := validateChecklistTitle(input.Title)
+	if err != nil {
+		return nil, err
+	}
+	position, err := optionalPosition(input.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	checklist := &models.Checklist{
+		CardID:       card.InternalId,
+		CardPublicID: card.PublicId,
+		Title:        title,
+	}
+	if err := s.checklistRepo.Create(checklist, position); err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
+
+// ListByCard mengambil semua checklist di kartu beserta item-nya. Cukup permission PermBoardView.
+func (s *checklistService) ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.Checklist, error) {
+	card, _, err := s.cardService.GetAuthorized(principal, cardPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+	return s.checklistRepo.FindByCard(card.InternalId)
+}
+
+// Update mengubah judul dan/atau posisi checklist di kartu. Butuh permission PermCardWrite.
+func (s *checklistService) Update(principal *utils.Principal, checklistPublicID uuid.UUID, input UpdateChecklistInput) (*models.Checklist, error) {
+	checklist, _, err := s.getAuthorized(principal, checklistPublicID, PermCardWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Position != nil && *input.Position < 0 {
+		return nil, fmt.Errorf("%w: position cannot be negative", ErrInvalidInput)
+	}
+	if input.Title != nil {
+		title, err := validateChecklistTitle(*input.Title)
+		if err != nil {
+			return nil, err
+		}
+		checklist.Title = title
+		if err := s.checklistRepo.Update(checklist); err != nil {
+			return nil, err
+		}
+	}
+	if input.Position != nil {
+		if err := s.checklistRepo.Move(checklist, *input.Position); err != nil {
+			return nil, err
+		}
+	}
+	return checklist, nil
+}
+
+// Delete menghapus checklist beserta semua item-nya. Butuh permission PermCardWrite.
+func (s *checklistService) Delete(principal *utils.Principal, checklistPublicID uuid.UUID) error {
+	checklist, _, err := s.getAuthorized(principal, checklistPublicID, PermCardWrite)
+	if err != nil {
+		return err
+	}
+	return s.checklistRepo.Delete(checklist)
+}
+
+// CreateItem menambah item ke checklist. Butuh permission PermCardWrite.
+// Assignee (jika diisi) WAJIB member board kartu tersebut.
+func (s *checklistService) CreateItem(principal *utils.Principal, checklistPublicID uuid.UUID, input CreateChecklistItemInput) (*models.ChecklistItem, error) {
+	checklist, list, err := s.getAuthorized(principal, checklistPublicID, PermCardWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := validateChecklistTitle(input.Title)
+	if err != nil {
+		return nil, err
+	}
+	position, err := optionalPosition(input.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &models.ChecklistItem{
+		ChecklistID:       checklist.InternalID,
+		ChecklistPublicID: checklist.PublicID,
+		CardID:            checklist.CardID,
+		Title:             title,
+		DueDate:           input.DueDate,
+	}
+	if input.AssigneeID != nil {
+		if err := s.setAssignee(list.BoardInternalID, item, *input.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.checklistRepo.CreateItem(item, position); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateItem mengubah item: judul, centang, posisi, assignee, atau due date.
+// Butuh permission PermCardWrite.
+func (s *checklistService) UpdateItem(principal *utils.Principal, itemPublicID uuid.UUID, input UpdateChecklistItemInput) (*models.ChecklistItem, error) {
+	item, list, err := s.getAuthorizedItem(principal, itemPublicID, PermCardWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Position != nil && *input.Position < 0 {
+		return nil, fmt.Errorf("%w: position cannot be negative", ErrInvalidInput)
+	}
+	if input.Title != nil {
+		title, err := validateChecklistTitle(*input.Title)
+		if err != nil {
+			return nil, err
+		}
+		item.Title = title
+	}
+
+	// CheckedAt hanya berubah saat status centang benar-benar berubah.
+	if input.Checked != nil && *input.Checked != item.Checked {
+		item.Checked = *input.Checked
+		item.CheckedAt = nil
+		if item.Checked {
+			now := time.Now()
+			item.CheckedAt = &now
+		}
+	}
+
+	if input.ClearAssignee {
+		item.AssigneeID, item.AssigneePublicID = nil, nil
+	} else if input.AssigneeID != nil {
+		if err := s.setAssignee(list.BoardInternalID, item, *input.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.ClearDueDate {
+		item.DueDate = nil
+	} else if input.DueDate != nil {
+		item.DueDate = input.DueDate
+	}
+
+	if err := s.checklistRepo.UpdateItem(item); err != nil {
+		return nil, err
+	}
+	if input.Position != nil {
+		if err := s.checklistRepo.MoveItem(item, *input.Position); err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+// DeleteItem menghapus item dari checklist. Butuh permission PermCardWrite.
+func (s *checklistService) DeleteItem(principal *utils.Principal, itemPublicID uuid.UUID) error {
+	item, _, err := s.getAuthorizedItem(principal, itemPublicID, PermCardWrite)
+	if err != nil {
+		return err
+	}
+	return s.checklistRepo.DeleteItem(item)
+}
+
+// setAssignee mengisi assignee item. User harus ada dan menjadi member board kartu.
+func (s *checklistService) setAssignee(boardID int64, item *models.ChecklistItem, userPublicID uuid.UUID) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if _, err := s.memberRepo.Find(boardID, user.InternalID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAssigneeNotMember
+		}
+		return err
+	}
+
+	item.AssigneeID, item.AssigneePublicID = &user.InternalID, &user.PublicID
+	return nil
+}
+
+// getAuthorized mengambil checklist lalu mengecek permission lewat kartunya.
+// Non-member mendapat ErrChecklistNotFound agar keberadaan checklist tidak bocor.
+func (s *checklistService) getAuthorized(principal *utils.Principal, checklistPublicID uuid.UUID, permission Permission) (*models.Checklist, *models.List, error) {
+	checklist, err := s.checklistRepo.FindByPublicID(checklistPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrChecklistNotFound
+		}
+		return nil, nil, err
+	}
+
+	_, list, err := s.cardService.GetAuthorized(principal, checklist.CardPublicID, permission)
+	if err != nil {
+		if errors.Is(err, ErrCardNotFound) {
+			return nil, nil, ErrChecklistNotFound
+		}
+		return nil, nil, err
+	}
+	return checklist, list, nil
+}
+
+// getAuthorizedItem mengambil item lalu mengecek permission lewat checklist dan kartunya.
+func (s *checklistService) getAuthorizedItem(principal *utils.Principal, itemPublicID uuid.UUID, permission Permission) (*models.ChecklistItem, *models.List, error) {
+	item, err := s.checklistRepo.FindItemByPublicID(itemPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrChecklistItemNotFound
+		}
+		return nil, nil, err
+	}
+
+	_, list, err := s.getAuthorized(principal, item.ChecklistPublicID, permission)
+	if err != nil {
+		if errors.Is(err, ErrChecklistNotFound) {
+			return nil, nil, ErrChecklistItemNotFound
+		}
+		return nil, nil, err
+	}
+	return item, list, nil
+}
+
+// validateChecklistTitle merapikan dan memvalidasi judul checklist atau item.
+func validateChecklistTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(title) > maxChecklistTitleLength {
+		return "", fmt.Errorf("%w: title must be at most %d characters", ErrInvalidInput, maxChecklistTitleLength)
+	}
+	return title, nil
+}
+
+// optionalPosition mengubah position opsional menjadi index untuk repository
+// (-1 berarti "paling bawah").
+func optionalPosition(position *int) (int, error) {
+	if position == nil {
+		return -1, nil
+	}
+	if *position < 0 {
+		return 0, fmt.Errorf("%w: position cannot be negative", ErrInvalidInput)
+	}
+	return *position, nil
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// maxCommentLength membatasi panjang komentar agar satu komentar tidak bisa berukuran megabyte.
+const maxCommentLength = 10000
+
+// CreateCommentInput adalah data untuk menulis komentar.
+// ParentID opsional: diisi PublicID komentar lain jika ini adalah balasan.
+type CreateCommentInput struct {
+	Message  string     `json:"message"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+// UpdateCommentInput adalah data untuk mengedit komentar.
+type UpdateCommentInput struct {
+	Message string `json:"message"`
+}
+
+// CommentService berisi aturan bisnis untuk komentar di kartu.
+type CommentService interface {
+	Create(principal *utils.Principal, cardPublicID uuid.UUID, input CreateCommentInput) (*models.Comment, error)
+	ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.Comment, error)
+	Update(principal *utils.Principal, commentPublicID uuid.UUID, input UpdateCommentInput) (*models.Comment, error)
+	Delete(principal *utils.Principal, commentPublicID uuid.UUID) error
+	ListRevisions(principal *utils.Principal, commentPublicID uuid.UUID) ([]models.CommentRevision, error)
+}
+
+type commentService struct {
+	commentRepo repositories.CommentRepository
+	memberRepo  repositories.BoardMemberRepository
+	cardService CardService
+	authz       AuthorizationService
+}
+
+// NewCommentService membuat CommentService dengan dependency yang dibutuhkan.
+func NewCommentService(
+	commentRepo repositories.CommentRepository,
+	memberRepo repositories.BoardMemberRepository,
+	cardService CardService,
+	authz AuthorizationService,
+) CommentService {
+	return &commentService{commentRepo: commentRepo, memberRepo: memberRepo, cardService: cardService, authz: authz}
+}
+
+// Create menulis komentar (atau balasan) di kartu. Butuh permission PermCommentCreate.
+//
+// Thread hanya satu tingkat: membalas sebuah balasan akan menempelkan komentar
+// ke komentar utamanya, seperti thread di Slack.
+func (s *commentService) Create(principal *utils.Principal, cardPublicID uuid.UUID, input CreateCommentInput) (*models.Comment, error) {
+	card, list, err := s.cardService.GetAuthorized(principal, cardPublicID, PermCommentCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := validateCommentMessage(input.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		CardID:    card.InternalId,
+		CardPubID: card.PublicId,
+		UserID:    principal.InternalID,
+		UserPubID: principal.PublicID,
+		Message:   message,
+	}
+
+	if input.ParentID != nil {
+		parent, err := s.commentRepo.FindByPublicID(*input.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCommentNotFound
+			}
+			return nil, err
+		}
+		if parent.CardID != card.InternalId {
+			return nil, fmt.Errorf("%w: parent comment belongs to a different card", ErrInvalidInput)
+		}
+		if parent.ParentID != nil {
+			comment.ParentID, comment.ParentPubID = parent.ParentID, parent.ParentPubID
+		} else {
+			comment.ParentID, comment.ParentPubID = &parent.InternalID, &parent.PublicID
+		}
+	}
+
+	mentions, err := s.resolveMentions(list.BoardInternalID, message)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.commentRepo.Create(comment, mentions); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// ListByCard mengambil semua komentar di kartu. Cukup permission PermBoardView.
+func (s *commentService) ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.Comment, error) {
+	card, _, err := s.cardService.GetAuthorized(principal, cardPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+	return s.commentRepo.FindByCard(card.InternalId)
+}
+
+// Update mengedit isi komentar. Hanya penulis komentar yang boleh mengedit,
+// dan ia harus masih punya permission PermCommentCreate di board.
+// Isi lama disimpan sebagai CommentRevision dan mention dihitung ulang.
+func (s *commentService) Update(principal *utils.Principal, commentPublicID uuid.UUID, input UpdateCommentInput) (*models.Comment, error) {
+	comment, list, err := s.getAuthorized(principal, commentPublicID, PermCommentCreate)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != principal.InternalID {
+		return nil, ErrForbidden
+	}
+
+	message, err := validateCommentMessage(input.Message)
+	if err != nil {
+		return nil, err
+	}
+	if message == comment.Message {
+		return comment, nil
+	}
+
+	mentions, err := s.resolveMentions(list.BoardInternalID, message)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &models.CommentRevision{
+		CommentID:        comment.InternalID,
+		Message:          comment.Message,
+		EditedByID:       principal.InternalID,
+		EditedByPublicID: principal.PublicID,
+	}
+
+	now := time.Now()
+	comment.Message = message
+	comment.EditedAt = &now
+	comment.UpdatedAt = now
+
+	if err := s.commentRepo.Update(comment, revision, mentions); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// Delete menghapus komentar (soft delete). Penulis boleh menghapus komentarnya sendiri;
+// komentar orang lain hanya bisa dihapus dengan permission PermCommentModerate.
+//
+// Balasan tidak ikut dihapus. Selama masih ada balasan, komentar utama yang dihapus tetap
+// muncul di ListByCard sebagai tombstone (deleted: true, tanpa isi).
+func (s *commentService) Delete(principal *utils.Principal, commentPublicID uuid.UUID) error {
+	comment, list, err := s.getAuthorized(principal, commentPublicID, PermBoardView)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != principal.InternalID {
+		if _, err := s.authz.Authorize(principal, list.BoardInternalID, PermCommentModerate); err != nil {
+			return err
+		}
+	}
+	return s.commentRepo.Delete(comment)
+}
+
+// ListRevisions mengambil riwayat edit komentar. Cukup permission PermBoardView.
+func (s *commentService) ListRevisions(principal *utils.Principal, commentPublicID uuid.UUID) ([]models.CommentRevision, error) {
+	comment, _, err := s.getAuthorized(principal, commentPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+	return s.commentRepo.FindRevisions(comment.InternalID)
+}
+
+// getAuthorized mengambil komentar lalu mengecek permission lewat kartunya.
+// Non-member mendapat ErrCommentNotFound agar keberadaan komentar tidak bocor.
+func (s *commentService) getAuthorized(principal *utils.Principal, commentPublicID uuid.UUID, permission Permission) (*models.Comment, *models.List, error) {
+	comment, err := s.commentRepo.FindByPublicID(commentPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCommentNotFound
+		}
+		return nil, nil, err
+	}
+
+	_, list, err := s.cardService.GetAuthorized(principal, comment.CardPubID, permission)
+	if err != nil {
+		if errors.Is(err, ErrCardNotFound) {
+			return nil, nil, ErrCommentNotFound
+		}
+		return nil, nil, err
+	}
+	return comment, list, nil
+}
+
+// resolveMentions mencocokkan "@handle" di message dengan member board (lihat utils.MentionHandles).
+// Handle yang tidak cocok dengan member mana pun diabaikan, sehingga user di luar board
+// tidak bisa di-mention.
+func (s *commentService) resolveMentions(boardID int64, message string) ([]models.CommentMention, error) {
+	mentions := []models.CommentMention{}
+
+	handles := utils.ParseMentions(message)
+	if len(handles) == 0 {
+		return mentions, nil
+	}
+
+	members, err := s.memberRepo.ListByBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	byHandle := make(map[string]models.BoardMemberDetail, len(members)*2)
+	for _, member := range members {
+		for _, handle := range utils.MentionHandles(member.Name, member.Email) {
+			if _, taken := byHandle[handle]; !taken {
+				byHandle[handle] = member
+			}
+		}
+	}
+
+	mentioned := make(map[int64]bool, len(handles))
+	for _, handle := range handles {
+		member, ok := byHandle[handle]
+		if !ok || mentioned[member.UserInternalID] {
+			continue
+		}
+		mentioned[member.UserInternalID] = true
+		mentions = append(mentions, models.CommentMention{
+			UserID:       member.UserInternalID,
+			UserPublicID: member.UserPublicID,
+			Handle:       handle,
+		})
+	}
+	return mentions, nil
+}
+
+// validateCommentMessage merapikan dan memvalidasi isi komentar.
+func validateCommentMessage(message string) (string, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", fmt.Errorf("%w: message is required", ErrInvalidInput)
+	}
+	if len(message) > maxCommentLength {
+		return "", fmt.Errorf("%w: message must be at most %d characters", ErrInvalidInput, maxCommentLength)
+	}
+	return message, nil
+}
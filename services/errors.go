@@ -0,0 +1,52 @@
+// Package services berisi aturan bisnis aplikasi.
+// Service memanggil repository untuk akses data, dan dipanggil oleh controller.
+package services
+
+import "errors"
+
+// Daftar error "sentinel" yang dikembalikan service.
+// Controller membandingkan error dengan errors.Is() untuk menentukan HTTP status yang tepat,
+// sehingga service tidak perlu tahu apa-apa tentang HTTP.
+var (
+	// ErrInvalidInput dipakai untuk error validasi. Biasanya dibungkus dengan detailnya:
+	// fmt.Errorf("%w: title is required", ErrInvalidInput)
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrForbidden dipakai saat user tidak punya hak akses untuk aksi tertentu.
+	ErrForbidden = errors.New("you do not have permission to perform this action")
+
+	ErrUserNotFound       = errors.New("user not found")
+	ErrEmailAlreadyUsed   = errors.New("email already registered")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid or expired")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, please login again")
+
+	ErrBoardNotFound = errors.New("board not found")
+	ErrListNotFound  = errors.New("list not found")
+	ErrCardNotFound  = errors.New("card not found")
+
+	// ErrCardMovedConcurrently berarti kartu dipindah/dihapus request lain di tengah proses;
+	// client cukup mengambil ulang datanya lalu mencoba lagi.
+	ErrCardMovedConcurrently = errors.New("card was moved by another request, please retry")
+
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrLabelNotFound   = errors.New("label not found")
+
+	ErrAssigneeNotMember = errors.New("assignee must be a member of the board")
+
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrFileTooLarge       = errors.New("file is too large")
+
+	ErrChecklistNotFound     = errors.New("checklist not found")
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+
+	ErrMemberNotFound          = errors.New("member not found")
+	ErrAlreadyMember           = errors.New("user is already a member of this board")
+	ErrCannotRemoveOwner       = errors.New("board owner cannot be removed from the board")
+	ErrCannotChangeOwner       = errors.New("board owner role cannot be changed")
+	ErrInvitationNotFound      = errors.New("invitation not found")
+	ErrInvitationExpired       = errors.New("invitation has expired")
+	ErrInvitationNotPending    = errors.New("invitation has already been responded to")
+	ErrInvitationEmailMismatch = errors.New("invitation was sent to a different email address")
+)
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/storage"
+)
+
+// readinessCheckTimeout adalah batas waktu SETIAP pengecekan readiness, agar probe
+// tidak menggantung saat database atau storage tidak merespons.
+const readinessCheckTimeout = 2 * time.Second
+
+// Status hasil pengecekan readiness.
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// HealthCheck adalah hasil satu pengecekan readiness (database, migrations, storage).
+type HealthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Readiness adalah hasil GET /readyz. Ready bernilai true hanya jika SEMUA pengecekan ok.
+type Readiness struct {
+	Ready  bool          `json:"ready"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// HealthService mengecek apakah instance ini siap menerima traffic.
+type HealthService interface {
+	Readiness(ctx context.Context) *Readiness
+}
+
+type healthService struct {
+	healthRepo repositories.HealthRepository
+	storage    storage.Storage
+}
+
+// NewHealthService membuat HealthService.
+func NewHealthService(healthRepo repositories.HealthRepository, store storage.Storage) HealthService {
+	return &healthService{healthRepo: healthRepo, storage: store}
+}
+
+// Readiness menjalankan semua pengecekan secara berurutan. Pengecekan berikutnya tetap
+// dijalankan walaupun yang sebelumnya gagal, agar response menunjukkan semua masalah sekaligus.
+func (s *healthService) Readiness(ctx context.Context) *Readiness {
+	readiness := &Readiness{Ready: true}
+	checks := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{"database", s.healthRepo.Ping},
+		{"migrations", s.checkMigrations},
+		{"storage", s.storage.Ping},
+	}
+
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+		err := check.run(checkCtx)
+		cancel()
+
+		result := HealthCheck{Name: check.name, Status: HealthStatusOK}
+		if err != nil {
+			result.Status = HealthStatusFail
+			result.Error = err.Error()
+			readiness.Ready = false
+		}
+		readiness.Checks = append(readiness.Checks, result)
+	}
+	return readiness
+}
+
+// checkMigrations gagal jika masih ada migration yang belum dijalankan atau database dirty.
+//
+// Database yang versinya LEBIH BARU dari binary ini dianggap siap: saat rolling deploy,
+// instance lama tetap melayani traffic setelah instance baru menjalankan migration.
+func (s *healthService) checkMigrations(ctx context.Context) error {
+	status, err := s.healthRepo.MigrationStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("database is dirty at version %d", status.Version)
+	}
+	if len(status.Pending) > 0 {
+		return fmt.Errorf("database is at version %d, %d pending migrations up to version %d",
+			status.Version, len(status.Pending), status.Latest)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// LabelPalette adalah nama warna yang boleh dipakai selain kode hex.
+// Frontend memetakan nama ini ke warna sesuai tema (terang/gelap).
+var LabelPalette = []string{
+	"green", "yellow", "orange", "red", "purple",
+	"blue", "sky", "lime", "pink", "black",
+}
+
+// hexColorPattern menerima "#rgb" atau "#rrggbb".
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// maxLabelNameLength sesuai dengan varchar(255) di tabel labels.
+const maxLabelNameLength = 255
+
+// CreateLabelInput adalah data untuk membuat label di board.
+type CreateLabelInput struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// UpdateLabelInput adalah data untuk mengubah label. Field nil berarti "tidak diubah".
+type UpdateLabelInput struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+// LabelService berisi aturan bisnis untuk label board dan penempelannya di kartu.
+type LabelService interface {
+	Create(principal *utils.Principal, boardPublicID uuid.UUID, input CreateLabelInput) (*models.Label, error)
+	ListByBoard(principal *utils.Principal, boardPublicID uuid.UUID) ([]models.Label, error)
+	Update(principal *utils.Principal, labelPublicID uuid.UUID, input UpdateLabelInput) (*models.Label, error)
+	Delete(principal *utils.Principal, labelPublicID uuid.UUID) error
+	ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.Label, error)
+	Attach(principal *utils.Principal, cardPublicID, labelPublicID uuid.UUID) error
+	Detach(principal *utils.Principal, cardPublicID, labelPublicID uuid.UUID) error
+}
+
+type labelService struct {
+	labelRepo    repositories.LabelRepository
+	boardService BoardService
+	cardService  CardService
+	authz        AuthorizationService
+}
+
+// NewLabelService membuat LabelService dengan dependency yang dibutuhkan.
+func NewLabelService(
+	labelRepo repositories.LabelRepository,
+	boardService BoardService,
+	cardService CardService,
+	authz AuthorizationService,
+) LabelService {
+	return &labelService{labelRepo: labelRepo, boardService: boardService, cardService: cardService, authz: authz}
+}
+
+// Create membuat label baru di board. Butuh permission PermLabelManage.
+func (s *labelService) Create(principal *utils.Principal, boardPublicID uuid.UUID, input CreateLabelInput) (*models.Label, error) {
+	board, _, err := s.boardService.GetAuthorized(principal, boardPublicID, PermLabelManage)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := validateLabelName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	color, err := normalizeLabelColor(input.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	label := &models.Label{
+		BoardID:       board.InternalID,
+		BoardPublicID: board.PublicID,
+		Name:          name,
+		Color:         color,
+	}
+	if err := s.labelRepo.Create(label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// ListByBoard mengambil semua label di board.
+func (s *labelService) ListByBoard(principal *utils.Principal, boardPublicID uuid.UUID) ([]models.Label, error) {
+	board, err := s.boardService.GetByPublicID(principal, boardPublicID)
+	if err != nil {
+		return nil, err
+	}
+	return s.labelRepo.FindByBoard(board.InternalID)
+}
+
+// Update mengubah nama dan/atau warna label. Butuh permission PermLabelManage.
+func (s *labelService) Update(principal *utils.Principal, labelPublicID uuid.UUID, input UpdateLabelInput) (*models.Label, error) {
+	label, err := s.getAuthorized(principal, labelPublicID, PermLabelManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name, err := validateLabelName(*input.Name)
+		if err != nil {
+			return nil, err
+		}
+		label.Name = name
+	}
+	if input.Color != nil {
+		color, err := normalizeLabelColor(*input.Color)
+		if err != nil {
+			return nil, err
+		}
+		label.Color = color
+	}
+
+	if err := s.labelRepo.Update(label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// Delete menghapus label beserta penempelannya di semua kartu. Butuh permission PermLabelManage.
+func (s *labelService) Delete(principal *utils.Principal, labelPublicID uuid.UUID) error {
+	label, err := s.getAuthorized(principal, labelPublicID, PermLabelManage)
+	if err != nil {
+		return err
+	}
+	return s.labelRepo.Delete(label)
+}
+
+// ListByCard mengambil label yang ditempel di kartu.
+func (s *labelService) ListByCard(principal *utils.Principal, cardPublicID uuid.UUID) ([]models.Label, error) {
+	card, _, err := s.cardService.GetAuthorized(principal, cardPublicID, PermBoardView)
+	if err != nil {
+		return nil, err
+	}
+	return s.labelRepo.FindByCard(card.InternalId)
+}
+
+// Attach menempelkan label ke kartu. Butuh permission PermCardWrite,
+// dan label harus milik board yang sama dengan kartu.
+func (s *labelService) Attach(principal *utils.Principal, cardPublicID, labelPublicID uuid.UUID) error {
+	card, label, err := s.cardAndLabel(principal, cardPublicID, labelPublicID)
+	if err != nil {
+		return err
+	}
+	return s.labelRepo.Attach(card.InternalId, label.InternalID)
+}
+
+// Detach melepas label dari kartu. Butuh permission PermCardWrite.
+func (s *labelService) Detach(principal *utils.Principal, cardPublicID, labelPublicID uuid.UUID) error {
+	card, label, err := s.cardAndLabel(principal, cardPublicID, labelPublicID)
+	if err != nil {
+		return err
+	}
+	return s.labelRepo.Detach(card.InternalId, label.InternalID)
+}
+
+// cardAndLabel mengambil kartu (dengan permission PermCardWrite) dan label milik board kartu tersebut.
+// Label dari board lain dianggap tidak ada (ErrLabelNotFound).
+func (s *labelService) cardAndLabel(principal *utils.Principal, cardPublicID, labelPublicID uuid.UUID) (*models.Card, *models.Label, error) {
+	card, list, err := s.cardService.GetAuthorized(principal, cardPublicID, PermCardWrite)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	label, err := s.labelRepo.FindByPublicID(labelPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrLabelNotFound
+		}
+		return nil, nil, err
+	}
+	if label.BoardID != list.BoardInternalID {
+		return nil, nil, ErrLabelNotFound
+	}
+	return card, label, nil
+}
+
+// getAuthorized mengambil label dan memastikan principal punya permission di board pemilik label.
+// Non-member mendapat ErrLabelNotFound agar keberadaan label tidak bocor.
+func (s *labelService) getAuthorized(principal *utils.Principal, labelPublicID uuid.UUID, permission Permission) (*models.Label, error) {
+	label, err := s.labelRepo.FindByPublicID(labelPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := s.authz.Authorize(principal, label.BoardID, permission); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+	return label, nil
+}
+
+// validateLabelName merapikan nama label. Nama boleh kosong (label warna saja).
+func validateLabelName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) > maxLabelNameLength {
+		return "", fmt.Errorf("%w: name must be at most %d characters", ErrInvalidInput, maxLabelNameLength)
+	}
+	return name, nil
+}
+
+// normalizeLabelColor memvalidasi warna label: kode hex ("#ff0000") atau nama dari LabelPalette.
+// Kode hex disimpan dalam huruf kecil agar mudah dibandingkan.
+func normalizeLabelColor(color string) (string, error) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if hexColorPattern.MatchString(color) {
+		return color, nil
+	}
+	for _, name := range LabelPalette {
+		if color == name {
+			return color, nil
+		}
+	}
+	return "", fmt.Errorf("%w: color must be a hex code (#rrggbb) or one of: %s",
+		ErrInvalidInput, strings.Join(LabelPalette, ", "))
+}
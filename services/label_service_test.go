@@ -0,0 +1,45 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeLabelColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"#ff0000", "#ff0000", false},
+		{"#FF00AA", "#ff00aa", false},
+		{"#abc", "#abc", false},
+		{"  #123456  ", "#123456", false},
+		{"green", "green", false},
+		{"Sky", "sky", false},
+		{" BLACK ", "black", false},
+		{"", "", true},
+		{"#ff00", "", true},
+		{"#gggggg", "", true},
+		{"ff0000", "", true},
+		{"#ff0000ff", "", true},
+		{"magenta", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := normalizeLabelColor(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("normalizeLabelColor(%q) error = %v, want ErrInvalidInput", tt.in, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("normalizeLabelColor(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeLabelColor(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/storage"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// CreateListInput adalah data untuk membuat list.
+// Position opsional: index tujuan (0 = paling kiri). Jika kosong, list ditaruh paling akhir.
+type CreateListInput struct {
+	Title    string `json:"title"`
+	Position *int   `json:"position"`
+}
+
+// UpdateListInput adalah data untuk mengganti judul list.
+type UpdateListInput struct {
+	Title string `json:"title"`
+}
+
+// MoveListInput adalah data untuk memindahkan urutan list di dalam board.
+type MoveListInput struct {
+	Position int `json:"position"`
+}
+
+// ListService berisi aturan bisnis untuk list di dalam board.
+type ListService interface {
+	Create(principal *utils.Principal, boardPublicID uuid.UUID, input CreateListInput) (*models.List, error)
+	ListByBoard(principal *utils.Principal, boardPublicID uuid.UUID) ([]models.List, error)
+	Update(principal *utils.Principal, listPublicID uuid.UUID, input UpdateListInput) (*models.List, error)
+	Delete(principal *utils.Principal, listPublicID uuid.UUID) error
+	Move(principal *utils.Principal, listPublicID uuid.UUID, input MoveListInput) error
+	GetAuthorized(principal *utils.Principal, listPublicID uuid.UUID, permission Permission) (*models.List, error)
+}
+
+type listService struct {
+	listRepo       repositories.ListRepository
+	attachmentRepo repositories.CardAttachmentRepository
+	storage        storage.Storage
+	boardService   BoardService
+	authz          AuthorizationService
+}
+
+// NewListService membuat ListService dengan dependency yang dibutuhkan.
+func NewListService(
+	listRepo repositories.ListRepository,
+	attachmentRepo repositories.CardAttachmentRepository,
+	store storage.Storage,
+	boardService BoardService,
+	authz AuthorizationService,
+) ListService {
+	return &listService{
+		listRepo:       listRepo,
+		attachmentRepo: attachmentRepo,
+		storage:        store,
+		boardService:   boardService,
+		authz:          authz,
+	}
+}
+
+// Create membuat list baru di board. Butuh permission PermListWrite.
+func (s *listService) Create(principal *utils.Principal, boardPublicID uuid.UUID, input CreateListInput) (*models.List, error) {
+	board, _, err := s.boardService.GetAuthorized(principal, boardPublicID, PermListWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+
+	position := -1
+	if input.Position != nil {
+		if *input.Position < 0 {
+			return nil, fmt.Errorf("%w: position cannot be negative", ErrInvalidInput)
+		}
+		position = *input.Position
+	}
+
+	list := &models.List{
+		Title:           title,
+		BoardPublicID:   board.PublicID,
+		BoardInternalID: board.InternalID,
+	}
+	if err := s.listRepo.Create(list, position); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ListByBoard mengambil semua list di board sesuai urutan yang tersimpan.
+func (s *listService) ListByBoard(principal *utils.Principal, boardPublicID uuid.UUID) ([]models.List, error) {
+	board, err := s.boardService.GetByPublicID(principal, boardPublicID)
+	if err != nil {
+		return nil, err
+	}
+	return s.listRepo.FindByBoardOrdered(board.InternalID)
+}
+
+// Update mengganti judul list. Butuh permission PermListWrite.
+func (s *listService) Update(principal *utils.Principal, listPublicID uuid.UUID, input UpdateListInput) (*models.List, error) {
+	list, err := s.GetAuthorized(principal, listPublicID, PermListWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+	list.Title = title
+
+	if err := s.listRepo.UpdateTitle(list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Delete menghapus list. Butuh permission PermListWrite.
+func (s *listService) Delete(principal *utils.Principal, listPublicID uuid.UUID) error {
+	list, err := s.GetAuthorized(principal, listPublicID, PermListWrite)
+	if err != nil {
+		return err
+	}
+	attachments, err := s.attachmentRepo.FindByList(list.InternalID)
+	if err != nil {
+		return err
+	}
+	if err := s.listRepo.Delete(list); err != nil {
+		return err
+	}
+	deleteAttachmentObjects(s.storage, attachments)
+	return nil
+}
+
+// Move memindahkan list ke index tertentu. Butuh permission PermListWrite.
+func (s *listService) Move(principal *utils.Principal, listPublicID uuid.UUID, input MoveListInput) error {
+	list, err := s.GetAuthorized(principal, listPublicID, PermListWrite)
+	if err != nil {
+		return err
+	}
+	if input.Position < 0 {
+		return fmt.Errorf("%w: position cannot be negative", ErrInvalidInput)
+	}
+	return s.listRepo.Move(list, input.Position)
+}
+
+// GetAuthorized mengambil list dan memastikan principal punya permission di board pemilik list.
+// Non-member mendapat ErrListNotFound agar keberadaan list tidak bocor.
+func (s *listService) GetAuthorized(principal *utils.Principal, listPublicID uuid.UUID, permission Permission) (*models.List, error) {
+	list, err := s.listRepo.FindByPublicID(listPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrListNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := s.authz.Authorize(principal, list.BoardInternalID, permission); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return nil, ErrListNotFound
+		}
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/rakafajars/go-manajemen-project/repositories"
+)
+
+const (
+	// rankMaxLength adalah panjang rank maksimal sebelum dirapikan. Rank yang panjang
+	// tetap benar, hanya boros ruang index, jadi tidak perlu dirapikan seketika.
+	rankMaxLength = 16
+
+	// rankRebalanceBatch membatasi jumlah board/list yang dirapikan per putaran
+	// agar satu putaran tidak menahan lock terlalu lama.
+	rankRebalanceBatch = 100
+)
+
+// RankRebalancer merapikan kolom rank list & kartu secara berkala (ORDERING_STRATEGY=rank).
+//
+// Setiap kali item disisipkan di antara dua tetangga, rank-nya bertambah panjang.
+// Rebalancer memberi ulang rank yang pendek dan berjarak merata, sekaligus mengisi
+// rank yang masih kosong dari urutan array lama (ListOrder/CardOrder).
+type RankRebalancer interface {
+	RunOnce() error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type rankRebalancer struct {
+	repo repositories.RankRepository
+}
+
+// NewRankRebalancer membuat RankRebalancer dengan dependency RankRepository.
+func NewRankRebalancer(repo repositories.RankRepository) RankRebalancer {
+	return &rankRebalancer{repo: repo}
+}
+
+// RunOnce merapikan semua board dan list yang rank-nya rusak.
+// Dipanggil sekali saat server start agar data lama sudah punya rank sebelum request pertama.
+func (r *rankRebalancer) RunOnce() error {
+	for {
+		boardIDs, err := r.repo.FindBoardsNeedingRebalance(rankMaxLength, rankRebalanceBatch)
+		if err != nil {
+			return err
+		}
+		for _, boardID := range boardIDs {
+			if err := r.repo.RebalanceBoard(boardID); err != nil {
+				return err
+			}
+		}
+		if len(boardIDs) < rankRebalanceBatch {
+			break
+		}
+	}
+
+	for {
+		listIDs, err := r.repo.FindListsNeedingRebalance(rankMaxLength, rankRebalanceBatch)
+		if err != nil {
+			return err
+		}
+		for _, listID := range listIDs {
+			if err := r.repo.RebalanceList(listID); err != nil {
+				return err
+			}
+		}
+		if len(listIDs) < rankRebalanceBatch {
+			break
+		}
+	}
+	return nil
+}
+
+// Run menjalankan RunOnce setiap interval sampai ctx dibatalkan.
+// Error hanya dicatat ke log: putaran berikutnya akan mencoba lagi.
+func (r *rankRebalancer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.RunOnce(); err != nil {
+				log.Println("Rank rebalance failed:", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+// SessionService berisi aturan bisnis logout dan pencabutan sesi.
+// Satu "sesi" = access token (JWT) + refresh token yang didapat saat login.
+type SessionService interface {
+	Logout(principal *utils.Principal, refreshToken string) error
+	LogoutAll(principal *utils.Principal) error
+	RevokeUserSessions(userPublicID uuid.UUID) error
+}
+
+type sessionService struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	revocations      TokenRevocationService
+}
+
+// NewSessionService membuat SessionService dengan dependency yang dibutuhkan.
+func NewSessionService(
+	userRepo repositories.UserRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revocations TokenRevocationService,
+) SessionService {
+	return &sessionService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revocations:      revocations,
+	}
+}
+
+// Logout mencabut access token yang sedang dipakai.
+// Jika client ikut mengirim refresh token miliknya, seluruh family refresh token itu juga dicabut
+// sehingga sesi ini benar-benar berakhir (tidak bisa di-refresh lagi).
+func (s *sessionService) Logout(principal *utils.Principal, refreshToken string) error {
+	if err := s.revocations.RevokeToken(principal); err != nil {
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	token, err := s.refreshTokenRepo.FindByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Jangan izinkan user mencabut refresh token milik orang lain.
+	if token.UserID != principal.InternalID {
+		return nil
+	}
+	return s.refreshTokenRepo.RevokeFamily(token.FamilyID)
+}
+
+// LogoutAll mencabut semua sesi milik user yang sedang login (di semua device).
+func (s *sessionService) LogoutAll(principal *utils.Principal) error {
+	return s.revokeAll(principal.InternalID)
+}
+
+// RevokeUserSessions dipakai admin untuk mencabut semua sesi milik user lain.
+func (s *sessionService) RevokeUserSessions(userPublicID uuid.UUID) error {
+	user, err := s.userRepo.FindByPublicID(userPublicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return s.revokeAll(user.InternalID)
+}
+
+// revokeAll mencabut semua refresh token dan access token milik user.
+func (s *sessionService) revokeAll(userID int64) error {
+	if err := s.refreshTokenRepo.RevokeAllForUser(userID); err != nil {
+		return err
+	}
+	return s.revocations.RevokeAllForUser(userID)
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/storage"
+	"github.com/rakafajars/go-manajemen-project/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// thumbnailMaxSize adalah sisi terpanjang thumbnail dalam pixel.
+	thumbnailMaxSize = 400
+
+	// thumbnailBatch membatasi jumlah attachment yang diambil per query.
+	thumbnailBatch = 20
+
+	// ThumbnailPollInterval adalah jeda pengecekan attachment "pending" jika tidak ada Notify,
+	// misal untuk attachment yang di-upload lewat instance server lain.
+	ThumbnailPollInterval = 30 * time.Second
+)
+
+// ThumbnailWorker membuat thumbnail attachment gambar di background.
+//
+// Upload tidak menunggu thumbnail selesai: attachment gambar disimpan dengan status "pending",
+// lalu worker mengambilnya dari database. Karena antrian ada di database (bukan di memory),
+// attachment yang belum sempat diproses saat server mati akan diproses saat server hidup lagi.
+type ThumbnailWorker interface {
+	// Notify memberi tahu worker bahwa ada attachment baru. Tidak pernah blocking.
+	Notify()
+	RunOnce(ctx context.Context) error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type thumbnailWorker struct {
+	attachmentRepo repositories.CardAttachmentRepository
+	storage        storage.Storage
+	wake           chan struct{}
+}
+
+// NewThumbnailWorker membuat ThumbnailWorker. Worker baru bekerja setelah Run dipanggil.
+func NewThumbnailWorker(attachmentRepo repositories.CardAttachmentRepository, store storage.Storage) ThumbnailWorker {
+	return &thumbnailWorker{
+		attachmentRepo: attachmentRepo,
+		storage:        store,
+		// Buffer 1: beberapa Notify berturut-turut cukup membangunkan worker sekali.
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Notify membangunkan worker. Jika worker sudah dijadwalkan bangun, panggilan ini diabaikan.
+func (w *thumbnailWorker) Notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RunOnce memproses semua attachment yang thumbnail-nya masih "pending".
+func (w *thumbnailWorker) RunOnce(ctx context.Context) error {
+	for {
+		attachments, err := w.attachmentRepo.FindPendingThumbnails(thumbnailBatch)
+		if err != nil {
+			return err
+		}
+		for i := range attachments {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := w.process(ctx, &attachments[i]); err != nil {
+				return err
+			}
+		}
+		if len(attachments) < thumbnailBatch {
+			return nil
+		}
+	}
+}
+
+// Run menjalankan RunOnce setiap kali Notify dipanggil atau setiap interval, sampai ctx dibatalkan.
+// Error hanya dicatat ke log: putaran berikutnya akan mencoba lagi.
+func (w *thumbnailWorker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Println("Thumbnail generation failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// process membuat thumbnail satu attachment.
+//
+// Gambar yang rusak atau terlalu besar ditandai "failed" agar tidak dicoba terus-menerus.
+// Error lain (storage/database sedang bermasalah) dikembalikan dan attachment tetap
+// "pending" untuk dicoba lagi di putaran berikutnya.
+func (w *thumbnailWorker) process(ctx context.Context, attachment *models.CardAttachment) error {
+	object, err := w.storage.Get(ctx, attachment.File)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return w.markFailed(attachment, err)
+		}
+		return err
+	}
+	thumb, err := utils.MakeThumbnail(object.Body, thumbnailMaxSize)
+	object.Body.Close()
+	if err != nil {
+		return w.markFailed(attachment, err)
+	}
+
+	thumbnailKey := attachment.File + "-thumb"
+	if err := w.storage.Put(ctx, thumbnailKey, bytes.NewReader(thumb.Data), int64(len(thumb.Data)), utils.ThumbnailContentType); err != nil {
+		return err
+	}
+
+	attachment.Width, attachment.Height = &thumb.Width, &thumb.Height
+	attachment.ThumbnailKey = thumbnailKey
+	attachment.ThumbnailStatus = models.ThumbnailStatusReady
+
+	err = w.attachmentRepo.UpdateThumbnail(attachment)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Attachment dihapus selama thumbnail dibuat: hapus thumbnail-nya agar tidak jadi sampah.
+		// Jika attachment masih ada, berarti worker lain sudah memprosesnya dengan key yang sama.
+		if _, findErr := w.attachmentRepo.FindByPublicID(attachment.PublicID); errors.Is(findErr, gorm.ErrRecordNotFound) {
+			return w.storage.Delete(ctx, thumbnailKey)
+		}
+		return nil
+	}
+	return err
+}
+
+// markFailed menandai thumbnail attachment gagal dibuat.
+func (w *thumbnailWorker) markFailed(attachment *models.CardAttachment, cause error) error {
+	log.Printf("Cannot create thumbnail for attachment %s: %v", attachment.PublicID, cause)
+
+	attachment.ThumbnailStatus = models.ThumbnailStatusFailed
+	if err := w.attachmentRepo.UpdateThumbnail(attachment); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
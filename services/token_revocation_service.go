@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rakafajars/go-manajemen-project/models"
+	"github.com/rakafajars/go-manajemen-project/repositories"
+	"github.com/rakafajars/go-manajemen-project/utils"
+)
+
+const (
+	// RevocationSyncInterval adalah seberapa sering cache disinkronkan dengan database.
+	// Revocation dari instance server lain paling lambat terlihat setelah interval ini.
+	RevocationSyncInterval = 15 * time.Second
+
+	// revocationSyncOverlap memundurkan titik sinkronisasi agar baris yang ditulis
+	// instance lain dengan jam yang sedikit berbeda tidak terlewat.
+	revocationSyncOverlap = time.Minute
+
+	// revocationMaxStaleness adalah umur maksimal cache. Jika sinkronisasi terus gagal
+	// melewati batas ini, IsRevoked mengembalikan error (request ditolak) daripada
+	// menerima token yang mungkin sudah dicabut di instance lain.
+	revocationMaxStaleness = 4 * RevocationSyncInterval
+)
+
+// errRevocationCacheStale dikembalikan IsRevoked jika cache belum pernah atau sudah lama
+// tidak berhasil disinkronkan.
+var errRevocationCacheStale = errors.New("token revocation cache is stale")
+
+// TokenRevocationService mengelola daftar access token yang dicabut.
+//
+// Sumber kebenaran ada di PostgreSQL, tetapi middleware auth memanggil IsRevoked
+// di SETIAP request, sehingga datanya di-cache di memory. Cache disinkronkan oleh
+// goroutine background (Run), bukan di jalur request.
+type TokenRevocationService interface {
+	IsRevoked(principal *utils.Principal) (bool, error)
+	RevokeToken(principal *utils.Principal) error
+	RevokeAllForUser(userID int64) error
+	Sync() error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type tokenRevocationService struct {
+	repo repositories.TokenRevocationRepository
+
+	// syncMu memastikan hanya satu Sync yang berjalan pada satu waktu.
+	syncMu sync.Mutex
+
+	// mu melindungi map di bawah karena diakses banyak goroutine (satu per request).
+	// Query database di Sync dijalankan TANPA memegang mu.
+	mu       sync.RWMutex
+	jtis     map[string]time.Time // jti -> waktu expired token
+	cutoffs  map[int64]time.Time  // user internal id -> RevokedBefore
+	lastSync time.Time
+}
+
+// NewTokenRevocationService membuat TokenRevocationService dengan cache kosong.
+// Panggil Sync sekali sebelum server menerima request, lalu jalankan Run di background.
+func NewTokenRevocationService(repo repositories.TokenRevocationRepository) TokenRevocationService {
+	return &tokenRevocationService{
+		repo:    repo,
+		jtis:    make(map[string]time.Time),
+		cutoffs: make(map[int64]time.Time),
+	}
+}
+
+// IsRevoked mengecek apakah token milik principal sudah dicabut,
+// baik per token (jti) maupun lewat "logout semua sesi" (iat <= RevokedBefore).
+// Hanya membaca cache, tidak pernah query ke database.
+func (s *tokenRevocationService) IsRevoked(principal *utils.Principal) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if time.Since(s.lastSync) > revocationMaxStaleness {
+		return false, errRevocationCacheStale
+	}
+	if _, revoked := s.jtis[principal.TokenID]; revoked {
+		return true, nil
+	}
+	if cutoff, ok := s.cutoffs[principal.InternalID]; ok && !principal.IssuedAt.After(cutoff) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// RevokeToken mencabut satu token (logout sesi ini).
+func (s *tokenRevocationService) RevokeToken(principal *utils.Principal) error {
+	jti, err := uuid.Parse(principal.TokenID)
+	if err != nil {
+		return err
+	}
+
+	row := &models.RevokedToken{
+		JTI:       jti,
+		UserID:    principal.InternalID,
+		ExpiresAt: principal.ExpiresAt,
+		RevokedAt: time.Now(),
+	}
+	if err := s.repo.RevokeToken(row); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.jtis[principal.TokenID] = principal.ExpiresAt
+	s.mu.Unlock()
+	return nil
+}
+
+// RevokeAllForUser mencabut semua token milik user yang sudah diterbitkan sampai saat ini.
+//
+// Claim "iat" berpresisi milidetik (lihat utils.GenerateToken), jadi batasnya juga dibulatkan
+// ke milidetik. Token dari login ulang setelah pencabutan ini tetap valid.
+func (s *tokenRevocationService) RevokeAllForUser(userID int64) error {
+	cutoff := time.Now().Truncate(time.Millisecond)
+	if err := s.repo.SetRevokedBefore(userID, cutoff); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.cutoffs[userID] = cutoff
+	s.mu.Unlock()
+	return nil
+}
+
+// Sync memuat perubahan dari database ke cache dan membuang jti yang sudah expired.
+// Sinkronisasi pertama memuat semua data.
+func (s *tokenRevocationService) Sync() error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	s.mu.RLock()
+	since := s.lastSync
+	s.mu.RUnlock()
+	if !since.IsZero() {
+		since = since.Add(-revocationSyncOverlap)
+	}
+	now := time.Now()
+
+	tokens, err := s.repo.FindRevokedTokensSince(since)
+	if err != nil {
+		return err
+	}
+	revocations, err := s.repo.FindUserRevocationsSince(since)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteExpired(now); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range tokens {
+		s.jtis[token.JTI.String()] = token.ExpiresAt
+	}
+	for _, revocation := range revocations {
+		// Jangan mundurkan batas yang baru saja ditulis RevokeAllForUser di instance ini.
+		if current, ok := s.cutoffs[revocation.UserID]; !ok || revocation.RevokedBefore.After(current) {
+			s.cutoffs[revocation.UserID] = revocation.RevokedBefore
+		}
+	}
+
+	// Buang jti yang token-nya sudah expired agar memory tidak terus membesar.
+	for jti, expiresAt := range s.jtis {
+		if !expiresAt.After(now) {
+			delete(s.jtis, jti)
+		}
+	}
+
+	s.lastSync = now
+	return nil
+}
+
+// Run menjalankan Sync setiap interval sampai ctx dibatalkan.
+// Error hanya dicatat ke log: putaran berikutnya akan mencoba lagi.
+func (s *tokenRevocationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Sync(); err != nil {
+				log.Println("Token revocation sync failed:", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// localStorage menyimpan object sebagai file biasa di bawah folder root.
+// Content type tidak disimpan di disk; pemanggil menyimpannya di database.
+type localStorage struct {
+	root string
+}
+
+// NewLocal membuat Storage berbasis folder lokal. Folder dibuat jika belum ada.
+func NewLocal(root string) (Storage, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+	return &localStorage{root: root}, nil
+}
+
+// Put menulis ke file sementara lalu me-rename-nya, sehingga pembaca tidak pernah
+// melihat file yang baru setengah tertulis.
+func (s *localStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op jika rename berhasil
+
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get membuka file untuk dibaca.
+func (s *localStorage) Get(ctx context.Context, key string) (*Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &Object{Body: file, Size: info.Size()}, nil
+}
+
+// Delete menghapus file.
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Ping memastikan folder root masih ada dan benar-benar folder
+// (misal volume yang di-mount belum terlepas).
+func (s *localStorage) Ping(ctx context.Context) error {
+	info, err := os.Stat(s.root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("storage: %q is not a directory", s.root)
+	}
+	return nil
+}
+
+// path mengubah key menjadi path file di bawah root.
+func (s *localStorage) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
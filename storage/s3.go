@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Options adalah konfigurasi koneksi ke Amazon S3 atau layanan kompatibel S3 (MinIO, dll).
+type S3Options struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+}
+
+// s3Storage menyimpan object di bucket S3.
+type s3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3 membuat Storage berbasis S3. Bucket dibuat otomatis jika belum ada,
+// sehingga MinIO lokal (lihat perintah `docker run` di bagian storage README) bisa langsung dipakai.
+func NewS3(ctx context.Context, opts S3Options) (Storage, error) {
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("storage: checking bucket %q: %w", opts.Bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, opts.Bucket, minio.MakeBucketOptions{Region: opts.Region}); err != nil {
+			return nil, fmt.Errorf("storage: creating bucket %q: %w", opts.Bucket, err)
+		}
+	}
+
+	return &s3Storage{client: client, bucket: opts.Bucket}, nil
+}
+
+// Put meng-upload object. Dengan size yang diketahui, minio-go memakai multipart upload
+// untuk file besar secara otomatis tanpa membaca seluruh file ke memory.
+func (s *s3Storage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.PutObject(ctx, s.bucket, key, reader, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// Get membuka object untuk dibaca secara streaming.
+func (s *s3Storage) Get(ctx context.Context, key string) (*Object, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, translateS3Error(err)
+	}
+
+	// GetObject bersifat lazy: error "tidak ditemukan" baru muncul saat Stat/Read.
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, translateS3Error(err)
+	}
+	return &Object{Body: object, Size: info.Size, ContentType: info.ContentType}, nil
+}
+
+// Delete menghapus object. S3 tidak mengembalikan error untuk object yang tidak ada.
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// Ping memastikan endpoint S3 bisa dijangkau dan bucket masih ada.
+func (s *s3Storage) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("storage: bucket %q does not exist", s.bucket)
+	}
+	return nil
+}
+
+// translateS3Error mengubah error "NoSuchKey" dari S3 menjadi ErrNotFound.
+func translateS3Error(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+// Package storage berisi abstraksi tempat penyimpanan file (attachment kartu).
+//
+// Service hanya bergantung pada interface Storage, sehingga file bisa disimpan
+// di disk lokal (development) atau di S3/MinIO (production) tanpa mengubah kode bisnis.
+// Driver dipilih lewat env STORAGE_DRIVER (lihat config.Config).
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rakafajars/go-manajemen-project/config"
+)
+
+// ErrNotFound dikembalikan jika object dengan key tersebut tidak ada.
+var ErrNotFound = errors.New("storage: object not found")
+
+// ErrInvalidKey dikembalikan jika key kosong atau mencoba keluar dari root ("../").
+var ErrInvalidKey = errors.New("storage: invalid object key")
+
+// Object adalah file yang dibaca dari Storage. Pemanggil WAJIB menutup Body.
+type Object struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+// Storage adalah kontrak tempat penyimpanan file.
+// Key memakai pemisah "/" (misal "cards/<uuid>/<uuid>") di semua driver.
+type Storage interface {
+	// Put menyimpan isi reader sebagai object key. size adalah jumlah byte isi reader.
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+
+	// Get membuka object untuk dibaca secara streaming (tidak dimuat penuh ke memory).
+	Get(ctx context.Context, key string) (*Object, error)
+
+	// Delete menghapus object. Menghapus object yang tidak ada tidak dianggap error.
+	Delete(ctx context.Context, key string) error
+
+	// Ping mengecek storage bisa dijangkau (folder root / bucket masih ada).
+	// Dipakai oleh readiness probe (GET /readyz).
+	Ping(ctx context.Context) error
+}
+
+// New membuat Storage sesuai cfg.StorageDriver.
+func New(ctx context.Context, cfg *config.Config) (Storage, error) {
+	switch cfg.StorageDriver {
+	case config.StorageLocal:
+		return NewLocal(cfg.StorageLocalPath)
+	case config.StorageS3:
+		return NewS3(ctx, S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			UseSSL:    cfg.S3UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", cfg.StorageDriver)
+	}
+}
+
+// validateKey menolak key kosong, absolut, atau yang mengandung segmen "..".
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"attachments/2025/03/file.pdf", true},
+		{"file.pdf", true},
+		{"a/b.c/d..e", true},
+		{"", false},
+		{"/etc/passwd", false},
+		{"attachments/../secret", false},
+		{"..", false},
+		{"./file.pdf", false},
+		{"attachments//file.pdf", false},
+		{"attachments/", false},
+		{`attachments\file.pdf`, false},
+	}
+
+	for _, tt := range tests {
+		err := validateKey(tt.key)
+		if tt.valid && err != nil {
+			t.Errorf("validateKey(%q) = %v, want nil", tt.key, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("validateKey(%q) = %v, want ErrInvalidKey", tt.key, err)
+		}
+	}
+}
+
+// testStorageRoundTrip menjalankan Put, Get, dan Delete yang sama untuk setiap driver.
+func testStorageRoundTrip(t *testing.T, store Storage) {
+	t.Helper()
+	ctx := context.Background()
+	key := "cards/" + uuid.NewString() + "/report.txt"
+	content := "isi file attachment"
+
+	if err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	object, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, err := io.ReadAll(object.Body)
+	object.Body.Close()
+	if err != nil {
+		t.Fatalf("read object: %v", err)
+	}
+	if string(data) != content || object.Size != int64(len(content)) {
+		t.Errorf("Get = %q (size %d), want %q (size %d)", data, object.Size, content, len(content))
+	}
+
+	// Put ulang ke key yang sama menimpa isi lama.
+	if err := store.Put(ctx, key, strings.NewReader("baru"), 4, "text/plain"); err != nil {
+		t.Fatalf("overwrite: %v", err)
+	}
+	object, err = store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get after overwrite: %v", err)
+	}
+	data, _ = io.ReadAll(object.Body)
+	object.Body.Close()
+	if string(data) != "baru" {
+		t.Errorf("Get after overwrite = %q, want %q", data, "baru")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing object = %v, want nil", err)
+	}
+	if err := store.Ping(ctx); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+}
+
+func TestLocalStorageRoundTrip(t *testing.T) {
+	store, err := NewLocal(filepath.Join(t.TempDir(), "uploads"))
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+	testStorageRoundTrip(t, store)
+}
+
+func TestLocalStorageRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "uploads")
+	store, err := NewLocal(root)
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+
+	// File di luar root yang tidak boleh bisa dibaca, ditimpa, atau dihapus lewat Storage.
+	secret := filepath.Join(dir, "secret.txt")
+	if err := os.WriteFile(secret, []byte("rahasia"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, key := range []string{"../secret.txt", "cards/../../secret.txt", secret, `..\secret.txt`} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+		if _, err := store.Get(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Get(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+		if err := store.Delete(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Delete(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+
+	if data, err := os.ReadFile(secret); err != nil || string(data) != "rahasia" {
+		t.Errorf("file outside root changed: %q, %v", data, err)
+	}
+}
+
+func TestLocalStoragePingMissingRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "uploads")
+	store, err := NewLocal(root)
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+	if err := os.RemoveAll(root); err != nil {
+		t.Fatalf("remove root: %v", err)
+	}
+	if err := store.Ping(context.Background()); err == nil {
+		t.Error("Ping succeeded after the root folder was removed")
+	}
+}
+
+// TestS3StorageRoundTrip berjalan terhadap MinIO lokal (lihat bagian storage di README).
+// Test dilewati jika TEST_S3_ENDPOINT kosong. Bucket dibuat otomatis oleh NewS3.
+//
+//	TEST_S3_ENDPOINT=localhost:9000 TEST_S3_ACCESS_KEY=minioadmin TEST_S3_SECRET_KEY=minioadmin go test ./storage
+func TestS3StorageRoundTrip(t *testing.T) {
+	endpoint := os.Getenv("TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("TEST_S3_ENDPOINT is not set")
+	}
+	bucket := os.Getenv("TEST_S3_BUCKET")
+	if bucket == "" {
+		bucket = "attachments-test"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	store, err := NewS3(ctx, S3Options{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		Bucket:    bucket,
+		AccessKey: os.Getenv("TEST_S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("TEST_S3_SECRET_KEY"),
+		UseSSL:    os.Getenv("TEST_S3_USE_SSL") == "true",
+	})
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+
+	testStorageRoundTrip(t, store)
+
+	if err := store.Put(ctx, "../escape", strings.NewReader("x"), 1, "text/plain"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put(\"../escape\") error = %v, want ErrInvalidKey", err)
+	}
+}
@@ -0,0 +1,269 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// KATALOG KODE ERROR
+// =============================================================================
+
+// ErrorCode adalah kode error yang bisa dibaca mesin, dikirim di field "code" response error.
+//
+// Client sebaiknya memakai field ini (bukan "message" atau "error") untuk menentukan
+// tindakan, misalnya meminta token baru saat menerima TOKEN_EXPIRED.
+//
+// PENTING: nilai kode adalah kontrak dengan client. Kode yang sudah ada TIDAK BOLEH diganti
+// atau dihapus; tambahkan kode baru jika butuh arti yang berbeda.
+type ErrorCode string
+
+// Kode umum, dipakai jika tidak ada kode yang lebih spesifik (lihat CodeForStatus).
+const (
+	CodeBadRequest         ErrorCode = "BAD_REQUEST"         // 400
+	CodeUnauthorized       ErrorCode = "UNAUTHORIZED"        // 401
+	CodeForbidden          ErrorCode = "FORBIDDEN"           // 403
+	CodeNotFound           ErrorCode = "NOT_FOUND"           // 404
+	CodeMethodNotAllowed   ErrorCode = "METHOD_NOT_ALLOWED"  // 405
+	CodeConflict           ErrorCode = "CONFLICT"            // 409
+	CodePayloadTooLarge    ErrorCode = "PAYLOAD_TOO_LARGE"   // 413
+	CodeValidationFailed   ErrorCode = "VALIDATION_FAILED"   // 422
+	CodeRateLimited        ErrorCode = "RATE_LIMITED"        // 429
+	CodeInternal           ErrorCode = "INTERNAL_ERROR"      // 500
+	CodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE" // 503
+)
+
+// Kode autentikasi.
+const (
+	CodeTokenRequired       ErrorCode = "TOKEN_REQUIRED"        // Header Authorization tidak ada
+	CodeTokenInvalid        ErrorCode = "TOKEN_INVALID"         // Token rusak, tanda tangan salah, atau sudah dicabut
+	CodeTokenExpired        ErrorCode = "TOKEN_EXPIRED"         // Access token kedaluwarsa: minta token baru lewat /auth/refresh
+	CodeInvalidCredentials  ErrorCode = "INVALID_CREDENTIALS"   // Email atau password salah
+	CodeInvalidRefreshToken ErrorCode = "INVALID_REFRESH_TOKEN" // Refresh token tidak valid atau kedaluwarsa
+	CodeRefreshTokenReused  ErrorCode = "REFRESH_TOKEN_REUSED"  // Refresh token lama dipakai ulang: semua sesi dicabut
+	CodeEmailAlreadyUsed    ErrorCode = "EMAIL_ALREADY_USED"
+)
+
+// Kode per resource (services.Err*).
+const (
+	CodeInvalidInput            ErrorCode = "INVALID_INPUT"
+	CodeUserNotFound            ErrorCode = "USER_NOT_FOUND"
+	CodeBoardNotFound           ErrorCode = "BOARD_NOT_FOUND"
+	CodeListNotFound            ErrorCode = "LIST_NOT_FOUND"
+	CodeCardNotFound            ErrorCode = "CARD_NOT_FOUND"
+	CodeCommentNotFound         ErrorCode = "COMMENT_NOT_FOUND"
+	CodeLabelNotFound           ErrorCode = "LABEL_NOT_FOUND"
+	CodeAttachmentNotFound      ErrorCode = "ATTACHMENT_NOT_FOUND"
+	CodeChecklistNotFound       ErrorCode = "CHECKLIST_NOT_FOUND"
+	CodeChecklistItemNotFound   ErrorCode = "CHECKLIST_ITEM_NOT_FOUND"
+	CodeMemberNotFound          ErrorCode = "MEMBER_NOT_FOUND"
+	CodeInvitationNotFound      ErrorCode = "INVITATION_NOT_FOUND"
+	CodeAlreadyMember           ErrorCode = "ALREADY_MEMBER"
+	CodeCannotRemoveOwner       ErrorCode = "CANNOT_REMOVE_OWNER"
+	CodeCannotChangeOwner       ErrorCode = "CANNOT_CHANGE_OWNER"
+	CodeInvitationExpired       ErrorCode = "INVITATION_EXPIRED"
+	CodeInvitationNotPending    ErrorCode = "INVITATION_NOT_PENDING"
+	CodeInvitationEmailMismatch ErrorCode = "INVITATION_EMAIL_MISMATCH"
+	CodeAssigneeNotMember       ErrorCode = "ASSIGNEE_NOT_MEMBER"
+	CodeFileTooLarge            ErrorCode = "FILE_TOO_LARGE"
+	CodeCardMovedConcurrently   ErrorCode = "CARD_MOVED_CONCURRENTLY"
+)
+
+// CodeForStatus mengembalikan kode umum untuk HTTP status, misal 404 -> NOT_FOUND.
+func CodeForStatus(status int) ErrorCode {
+	switch status {
+	case fiber.StatusBadRequest:
+		return CodeBadRequest
+	case fiber.StatusUnauthorized:
+		return CodeUnauthorized
+	case fiber.StatusForbidden:
+		return CodeForbidden
+	case fiber.StatusNotFound:
+		return CodeNotFound
+	case fiber.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case fiber.StatusConflict:
+		return CodeConflict
+	case fiber.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case fiber.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	case fiber.StatusTooManyRequests:
+		return CodeRateLimited
+	case fiber.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// =============================================================================
+// APP ERROR
+// =============================================================================
+
+// AppError adalah error aplikasi yang sudah tahu bagaimana ia harus dikirim ke client.
+//
+// Handler cukup me-return AppError, lalu ErrorHandler mengubahnya menjadi response
+// dengan format Response yang sama seperti helper BadRequest, NotFound, dll:
+//
+//	{
+//	  "status": "Error Conflict",
+//	  "response_code": 409,
+//	  "code": "EMAIL_ALREADY_USED",
+//	  "message": "Registration failed",
+//	  "error": "email already registered"
+//	}
+type AppError struct {
+	Status  int         // HTTP status
+	Code    ErrorCode   // Kode untuk client (lihat katalog di atas)
+	Message string      // Pesan singkat untuk manusia
+	Details interface{} // Data tambahan (opsional), misal daftar field yang tidak valid
+
+	// RetryAfter (opsional) dikirim sebagai header Retry-After, dipakai untuk 429.
+	RetryAfter time.Duration
+
+	// Err adalah penyebab asli. Pesannya dikirim di field "error", kecuali error 500
+	// di production (lihat errorResponse).
+	Err error
+}
+
+// NewAppError membuat AppError dengan status, kode, dan pesan tertentu.
+func NewAppError(status int, code ErrorCode, message string) *AppError {
+	return &AppError{Status: status, Code: code, Message: message}
+}
+
+// Error mengimplementasikan interface error.
+func (e *AppError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Unwrap membuat errors.Is/errors.As bisa memeriksa penyebab aslinya.
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Wrap mengembalikan salinan AppError dengan penyebab err.
+func (e *AppError) Wrap(err error) *AppError {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+// WithDetails mengembalikan salinan AppError dengan data tambahan details.
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
+// NewForbiddenError membuat AppError 403: user sudah login tapi tidak punya hak akses.
+func NewForbiddenError(message string) *AppError {
+	return NewAppError(fiber.StatusForbidden, CodeForbidden, message)
+}
+
+// NewConflictError membuat AppError 409: data bentrok dengan data yang sudah ada,
+// misal email yang sudah terdaftar.
+func NewConflictError(code ErrorCode, message string) *AppError {
+	return NewAppError(fiber.StatusConflict, code, message)
+}
+
+// NewValidationError membuat AppError 422: format request benar, tapi isinya tidak valid.
+// details biasanya berisi pesan per field, misal map[string]string{"title": "is required"}.
+func NewValidationError(message string, details interface{}) *AppError {
+	return NewAppError(fiber.StatusUnprocessableEntity, CodeValidationFailed, message).WithDetails(details)
+}
+
+// NewRateLimitError membuat AppError 429: client mengirim terlalu banyak request.
+// retryAfter (boleh 0) memberi tahu client kapan boleh mencoba lagi.
+func NewRateLimitError(message string, retryAfter time.Duration) *AppError {
+	appErr := NewAppError(fiber.StatusTooManyRequests, CodeRateLimited, message)
+	appErr.RetryAfter = retryAfter
+	return appErr
+}
+
+// =============================================================================
+// ERROR HANDLER
+// =============================================================================
+
+// pgUniqueViolation adalah SQLSTATE PostgreSQL untuk pelanggaran constraint UNIQUE.
+const pgUniqueViolation = "23505"
+
+// AsAppError mengubah error apa pun menjadi AppError:
+//   - AppError dengan status selain 500 dipakai apa adanya.
+//   - gorm.ErrRecordNotFound menjadi 404 NOT_FOUND.
+//   - Pelanggaran UNIQUE (gorm.ErrDuplicatedKey / SQLSTATE 23505) menjadi 409 CONFLICT.
+//   - *fiber.Error (route tidak ada, body terlalu besar, dll) memakai status-nya.
+//   - Selain itu menjadi 500 INTERNAL_ERROR.
+//
+// message dipakai jika err belum membawa pesan sendiri; kosong berarti pesan standar.
+func AsAppError(err error, message string) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		if appErr.Status != fiber.StatusInternalServerError {
+			return appErr
+		}
+		message = appErr.Message
+	}
+	messageOr := func(fallback string) string {
+		if message == "" {
+			return fallback
+		}
+		return message
+	}
+
+	var pgErr *pgconn.PgError
+	var fiberErr *fiber.Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NewAppError(fiber.StatusNotFound, CodeNotFound, messageOr("Resource not found")).Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey), errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+		// Pesan asli PostgreSQL berisi nama constraint & nilai yang bentrok, jadi tidak dikirim ke client.
+		return NewConflictError(CodeConflict, messageOr("Resource already exists")).Wrap(errDuplicate)
+	case errors.As(err, &fiberErr):
+		return NewAppError(fiberErr.Code, CodeForStatus(fiberErr.Code), fiberErr.Message)
+	case appErr != nil:
+		return appErr
+	default:
+		return NewAppError(fiber.StatusInternalServerError, CodeInternal, messageOr("Internal server error")).Wrap(err)
+	}
+}
+
+// errDuplicate adalah isi field "error" untuk pelanggaran constraint UNIQUE.
+var errDuplicate = errors.New("resource already exists")
+
+// ErrorHandler adalah fiber.Config.ErrorHandler aplikasi. Semua error yang di-return handler
+// atau middleware (termasuk error bawaan Fiber seperti 404 route tidak ditemukan) dikirim
+// dengan format Response dan field "code".
+//
+// Contoh penggunaan:
+//
+//	app := fiber.New(fiber.Config{ErrorHandler: utils.ErrorHandler})
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	return SendError(c, AsAppError(err, ""))
+}
+
+// SendError mengirim AppError ke client dengan format Response.
+func SendError(c *fiber.Ctx, appErr *AppError) error {
+	if appErr.RetryAfter > 0 {
+		// Retry-After dalam detik, dibulatkan ke atas agar client tidak mencoba terlalu cepat.
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+	}
+
+	var cause string
+	if appErr.Err != nil {
+		cause = appErr.Err.Error()
+	}
+	return errorResponse(c, appErr.Status, appErr.Code, appErr.Message, cause, appErr.Details)
+}
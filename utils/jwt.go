@@ -2,7 +2,13 @@
 package utils
 
 import (
-	"time" // Library standar Go untuk mengelola waktu
+	"crypto/rand"     // Generator angka acak yang aman untuk kriptografi
+	"crypto/sha256"   // Algoritma hash SHA-256
+	"encoding/base64" // Encoding byte acak menjadi string yang aman untuk URL
+	"encoding/hex"    // Encoding hasil hash menjadi string hexadecimal
+	"errors"          // Library standar Go untuk membuat error
+	"math"            // Pembulatan claim iat ke milidetik
+	"time"            // Library standar Go untuk mengelola waktu
 
 	"github.com/golang-jwt/jwt/v5"                      // Library untuk membuat dan memvalidasi JWT
 	"github.com/google/uuid"                            // Library untuk tipe data UUID
@@ -24,23 +30,31 @@ func GenerateToken(userID int64, role, email string, publicID uuid.UUID) (string
 	// Contoh secret: "mysupersecretkey123"
 	secret := config.AppConfig.JWTSecret
 
-	// Parse durasi expired token dari config
-	// Contoh: "24h" = 24 jam, "30m" = 30 menit, "7d" tidak valid (gunakan "168h" untuk 7 hari)
-	// Tanda _ artinya kita mengabaikan error (dalam production sebaiknya di-handle)
-	duration, _ := time.ParseDuration(config.AppConfig.JWTExpire)
+	// Durasi expired token dari config (JWT_EXPIRED), sudah di-parse dan divalidasi saat start
+	// sehingga di sini pasti bernilai positif.
+	duration := config.AppConfig.JWTExpire
 
 	// Claims adalah data yang akan disimpan di dalam token
 	// jwt.MapClaims adalah tipe map[string]interface{} untuk menyimpan data bebas
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id":   userID,   // ID user untuk identifikasi
 		"role":      role,     // Role untuk cek permission (authorization)
 		"email":     email,    // Email user
 		"public_id": publicID, // Public ID untuk response ke client
+		// "jti" (JWT ID) adalah ID unik setiap token.
+		// Dipakai untuk mencabut (revoke) satu token tertentu saat logout.
+		"jti": uuid.NewString(),
+		// "iat" (issued at) adalah waktu token dibuat.
+		// Dipakai untuk mencabut semua token yang dibuat sebelum waktu tertentu (logout semua sesi).
+		// Presisinya milidetik (angka desimal, diperbolehkan oleh RFC 7519), supaya token dari
+		// login ulang sesaat setelah "logout semua sesi" tidak ikut tercabut.
+		"iat": float64(now.UnixMilli()) / 1000,
 		// "exp" adalah expiration time - kapan token kadaluarsa
 		// time.Now() = waktu sekarang
 		// .Add(duration) = tambah durasi (misal 24 jam)
 		// .Unix() = convert ke Unix timestamp (angka detik sejak 1 Jan 1970)
-		"exp": time.Now().Add(duration).Unix(),
+		"exp": now.Add(duration).Unix(),
 	}
 
 	// Buat token baru dengan:
@@ -54,4 +68,137 @@ func GenerateToken(userID int64, role, email string, publicID uuid.UUID) (string
 	return token.SignedString([]byte(secret))
 }
 
-// TODO: generate refresh token
+// Principal adalah identitas user yang sedang login, hasil dari token JWT yang valid.
+// Middleware auth menyimpan struct ini di c.Locals agar handler tidak perlu membaca claims mentah.
+type Principal struct {
+	InternalID int64     // Diambil dari claim "user_id"
+	PublicID   uuid.UUID // Diambil dari claim "public_id"
+	Role       string    // Diambil dari claim "role"
+	Email      string    // Diambil dari claim "email"
+	TokenID    string    // Diambil dari claim "jti"
+	IssuedAt   time.Time // Diambil dari claim "iat"
+	ExpiresAt  time.Time // Diambil dari claim "exp"
+}
+
+// IsAdmin mengecek apakah user memiliki role global "admin".
+func (p *Principal) IsAdmin() bool {
+	return p.Role == "admin"
+}
+
+// ParseToken memvalidasi token JWT lalu mengembalikan Principal di dalamnya.
+//
+// Token dianggap valid jika:
+//   - Algoritmanya HS256 (token dengan algoritma lain, termasuk "none", ditolak)
+//   - Tanda tangannya cocok dengan JWTSecret
+//   - Claim "exp" ada dan belum lewat
+//
+// Return:
+//   - *Principal: Identitas user dari claims yang dibuat oleh GenerateToken
+//   - error: Error jika token rusak, dipalsukan, atau sudah kadaluarsa
+func ParseToken(tokenString string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+
+	// jwt.ParseWithClaims memecah token, mengecek tanda tangan dengan key dari callback,
+	// lalu mengisi claims. Opsi WithValidMethods mencegah serangan "algorithm confusion".
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(config.AppConfig.JWTSecret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return principalFromClaims(claims)
+}
+
+// principalFromClaims mengubah jwt.MapClaims menjadi Principal yang bertipe jelas.
+//
+// Catatan: setelah di-decode dari JSON, semua angka di MapClaims bertipe float64
+// dan UUID bertipe string, jadi perlu dikonversi satu per satu.
+func principalFromClaims(claims jwt.MapClaims) (*Principal, error) {
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, errors.New("invalid user_id claim")
+	}
+
+	rawPublicID, _ := claims["public_id"].(string)
+	publicID, err := uuid.Parse(rawPublicID)
+	if err != nil {
+		return nil, errors.New("invalid public_id claim")
+	}
+
+	tokenID, _ := claims["jti"].(string)
+	if tokenID == "" {
+		return nil, errors.New("invalid jti claim")
+	}
+
+	// GetIssuedAt membulatkan ke detik (jwt.TimePrecision), jadi iat dibaca sendiri
+	// agar presisi milidetiknya tidak hilang.
+	rawIssuedAt, ok := claims["iat"].(float64)
+	if !ok {
+		return nil, errors.New("invalid iat claim")
+	}
+	issuedAt := time.UnixMilli(int64(math.Round(rawIssuedAt * 1000)))
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, errors.New("invalid exp claim")
+	}
+
+	role, _ := claims["role"].(string)
+	email, _ := claims["email"].(string)
+
+	return &Principal{
+		InternalID: int64(userID),
+		PublicID:   publicID,
+		Role:       role,
+		Email:      email,
+		TokenID:    tokenID,
+		IssuedAt:   issuedAt,
+		ExpiresAt:  expiresAt.Time,
+	}, nil
+}
+
+// opaqueTokenBytes adalah panjang (byte) token acak. 32 byte = 256 bit, mustahil ditebak.
+const opaqueTokenBytes = 32
+
+// GenerateOpaqueToken membuat token "opaque" (string acak, bukan JWT).
+// Dipakai untuk refresh token maupun token undangan board.
+//
+// Return:
+//   - token: Token asli yang dikirim ke client (HANYA sekali, tidak disimpan)
+//   - hash: Hash SHA-256 dari token yang disimpan di database
+//   - error: Error jika generator acak OS gagal
+func GenerateOpaqueToken() (token string, hash string, err error) {
+	buf := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+// GenerateRefreshToken membuat refresh token opaque beserta hash-nya.
+func GenerateRefreshToken() (token string, hash string, err error) {
+	return GenerateOpaqueToken()
+}
+
+// HashToken menghasilkan hash SHA-256 (hex) dari sebuah token opaque.
+// Dipakai untuk menyimpan dan mencari token di database tanpa menyimpan aslinya.
+//
+// Kenapa SHA-256 dan bukan bcrypt? Token sudah acak 256 bit sehingga tidak bisa di-brute-force,
+// dan kita perlu hash yang deterministik agar bisa dicari dengan WHERE token_hash = ?.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RefreshTokenTTL mengembalikan masa berlaku refresh token dari config (REFRESH_TOKEN_EXPIRED).
+func RefreshTokenTTL() time.Duration {
+	return config.AppConfig.RefreshTokenTTL
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// rankAlphabet adalah digit yang dipakai rank (basis 36). Urutannya sama dengan urutan
+// byte ASCII, sehingga perbandingan string biasa ("a" < "b") sama dengan perbandingan angka.
+const rankAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const rankBase = len(rankAlphabet)
+
+// ErrInvalidRank dikembalikan jika rank berisi karakter di luar rankAlphabet,
+// diakhiri "0", atau prev tidak lebih kecil dari next.
+var ErrInvalidRank = errors.New("invalid rank")
+
+// RankBetween menghasilkan rank (fractional index ala LexoRank) yang berada DI ANTARA prev dan next.
+//
+// Rank adalah string yang diurutkan secara leksikografis, misal "a" < "ai" < "b".
+// Karena selalu ada string di antara dua string yang berbeda, memindahkan item cukup
+// mengubah rank item itu saja, tanpa menyentuh item lain.
+//
+// prev kosong berarti "sebelum item pertama", next kosong berarti "setelah item terakhir".
+//
+//	RankBetween("", "")   // "i"
+//	RankBetween("a", "b") // "ai"
+//	RankBetween("a", "")  // "n"
+func RankBetween(prev, next string) (string, error) {
+	if !validRank(prev) || !validRank(next) {
+		return "", ErrInvalidRank
+	}
+	if next != "" && prev >= next {
+		return "", ErrInvalidRank
+	}
+	return rankMidpoint(prev, next), nil
+}
+
+// RankSequence menghasilkan n rank yang jaraknya merata, dipakai saat rebalance dan backfill.
+// Panjang rank dibuat sependek mungkin agar masih banyak "ruang" untuk disisipi.
+func RankSequence(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	// Cari panjang digit terkecil yang muat n+1 celah.
+	width, space := 1, rankBase
+	for space <= n {
+		width++
+		space *= rankBase
+	}
+	step := space / (n + 1)
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		ranks[i] = encodeRank((i+1)*step, width)
+	}
+	return ranks
+}
+
+// rankMidpoint adalah inti RankBetween. Diasumsikan prev < next (next kosong = tak hingga)
+// dan keduanya tidak diakhiri digit "0".
+func rankMidpoint(prev, next string) string {
+	if next != "" {
+		// Lewati prefix yang sama. prev yang lebih pendek dianggap diisi "0".
+		n := 0
+		for n < len(next) && rankDigitAt(prev, n) == rankDigit(next[n]) {
+			n++
+		}
+		if n > 0 {
+			return next[:n] + rankMidpoint(suffix(prev, n), next[n:])
+		}
+	}
+
+	lo := rankDigitAt(prev, 0)
+	hi := rankBase
+	if next != "" {
+		hi = rankDigit(next[0])
+	}
+
+	if hi-lo > 1 {
+		return string(rankAlphabet[(lo+hi)/2])
+	}
+	// Digit pertama berurutan (misal "a" dan "b"). Jika next lebih panjang,
+	// digit pertamanya saja sudah di antara prev dan next.
+	if len(next) > 1 {
+		return next[:1]
+	}
+	// Jika tidak, ambil digit pertama prev lalu cari titik tengah di posisi berikutnya.
+	return string(rankAlphabet[lo]) + rankMidpoint(suffix(prev, 1), "")
+}
+
+// encodeRank mengubah angka menjadi rank basis 36 dengan panjang width,
+// lalu membuang "0" di akhir (tidak mengubah urutan karena "0" adalah digit terkecil).
+func encodeRank(value, width int) string {
+	digits := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		digits[i] = rankAlphabet[value%rankBase]
+		value /= rankBase
+	}
+	return strings.TrimRight(string(digits), "0")
+}
+
+// validRank mengecek karakter rank dan memastikan tidak diakhiri "0".
+// Rank yang diakhiri "0" tidak punya ruang di depannya ("a0" dan "a" setara).
+func validRank(rank string) bool {
+	for i := 0; i < len(rank); i++ {
+		if rankDigit(rank[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(rank, "0")
+}
+
+func rankDigit(c byte) int {
+	return strings.IndexByte(rankAlphabet, c)
+}
+
+// rankDigitAt mengembalikan digit ke-i dari rank, atau 0 jika rank lebih pendek.
+func rankDigitAt(rank string, i int) int {
+	if i < len(rank) {
+		return rankDigit(rank[i])
+	}
+	return 0
+}
+
+func suffix(s string, n int) string {
+	if n >= len(s) {
+		return ""
+	}
+	return s[n:]
+}
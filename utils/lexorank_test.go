@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// assertRankBetween memastikan rank valid (karakter benar, tidak diakhiri "0") dan
+// prev < rank < next (next kosong = tak hingga).
+func assertRankBetween(t *testing.T, prev, rank, next string) {
+	t.Helper()
+	if !validRank(rank) || rank == "" {
+		t.Fatalf("RankBetween(%q, %q) = %q, which is not a valid rank", prev, next, rank)
+	}
+	if rank <= prev {
+		t.Fatalf("RankBetween(%q, %q) = %q, want greater than %q", prev, next, rank, prev)
+	}
+	if next != "" && rank >= next {
+		t.Fatalf("RankBetween(%q, %q) = %q, want less than %q", prev, next, rank, next)
+	}
+}
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		prev, next string
+		want       string
+	}{
+		{"", "", "i"},
+		{"a", "b", "ai"},
+		{"a", "", "n"},
+		{"", "b", "5"},
+		{"", "1", "0i"},
+		{"", "01", "00i"},
+		{"a", "a1", "a0i"},
+		{"a", "c", "b"},
+		{"a", "bz", "b"},
+		{"az", "b", "azi"},
+		{"a5", "b", "ak"},
+		{"z", "", "zi"},
+		{"zz", "", "zzi"},
+		{"0i", "1", "0r"},
+		{"abc", "abd", "abci"},
+	}
+
+	for _, tt := range tests {
+		got, err := RankBetween(tt.prev, tt.next)
+		if err != nil {
+			t.Fatalf("RankBetween(%q, %q) returned error: %v", tt.prev, tt.next, err)
+		}
+		if got != tt.want {
+			t.Errorf("RankBetween(%q, %q) = %q, want %q", tt.prev, tt.next, got, tt.want)
+		}
+		assertRankBetween(t, tt.prev, got, tt.next)
+	}
+}
+
+func TestRankBetweenInvalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next string
+	}{
+		{"prev equals next", "a", "a"},
+		{"prev after next", "b", "a"},
+		{"prev ends with zero", "a0", "b"},
+		{"next ends with zero", "a", "b0"},
+		{"next is only zero", "", "0"},
+		{"uppercase digit", "A", ""},
+		{"character outside alphabet", "a", "b-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RankBetween(tt.prev, tt.next); !errors.Is(err, ErrInvalidRank) {
+				t.Errorf("RankBetween(%q, %q) error = %v, want ErrInvalidRank", tt.prev, tt.next, err)
+			}
+		})
+	}
+}
+
+func TestRankBetweenRepeatedHeadInsert(t *testing.T) {
+	first := "i"
+	for i := 0; i < 500; i++ {
+		rank, err := RankBetween("", first)
+		if err != nil {
+			t.Fatalf("insert #%d before %q: %v", i, first, err)
+		}
+		assertRankBetween(t, "", rank, first)
+		first = rank
+	}
+}
+
+func TestRankBetweenRepeatedTailInsert(t *testing.T) {
+	last := "i"
+	for i := 0; i < 500; i++ {
+		rank, err := RankBetween(last, "")
+		if err != nil {
+			t.Fatalf("insert #%d after %q: %v", i, last, err)
+		}
+		assertRankBetween(t, last, rank, "")
+		last = rank
+	}
+}
+
+func TestRankBetweenRepeatedInsertAfterFirst(t *testing.T) {
+	// Selalu menyisipkan tepat setelah item pertama: rank makin panjang ke arah prev.
+	prev, next := "a", "b"
+	for i := 0; i < 500; i++ {
+		rank, err := RankBetween(prev, next)
+		if err != nil {
+			t.Fatalf("insert #%d between %q and %q: %v", i, prev, next, err)
+		}
+		assertRankBetween(t, prev, rank, next)
+		next = rank
+	}
+}
+
+func TestRankBetweenRandomInserts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ranks := []string{}
+
+	for i := 0; i < 2000; i++ {
+		index := rng.Intn(len(ranks) + 1)
+		var prev, next string
+		if index > 0 {
+			prev = ranks[index-1]
+		}
+		if index < len(ranks) {
+			next = ranks[index]
+		}
+
+		rank, err := RankBetween(prev, next)
+		if err != nil {
+			t.Fatalf("insert #%d between %q and %q: %v", i, prev, next, err)
+		}
+		assertRankBetween(t, prev, rank, next)
+
+		ranks = append(ranks, "")
+		copy(ranks[index+1:], ranks[index:])
+		ranks[index] = rank
+	}
+
+	if !sort.StringsAreSorted(ranks) {
+		t.Fatal("ranks are not sorted after random inserts")
+	}
+}
+
+func TestRankSequence(t *testing.T) {
+	tests := []struct {
+		n         int
+		wantWidth int // Panjang maksimal rank yang diharapkan
+	}{
+		{0, 0},
+		{1, 1},
+		{35, 1},
+		{36, 2},
+		{1295, 2},
+		{1296, 3},
+	}
+
+	for _, tt := range tests {
+		ranks := RankSequence(tt.n)
+		if len(ranks) != tt.n {
+			t.Fatalf("RankSequence(%d) returned %d ranks", tt.n, len(ranks))
+		}
+
+		for i, rank := range ranks {
+			if rank == "" || !validRank(rank) {
+				t.Fatalf("RankSequence(%d)[%d] = %q is not a valid rank", tt.n, i, rank)
+			}
+			if len(rank) > tt.wantWidth {
+				t.Errorf("RankSequence(%d)[%d] = %q, want at most %d digits", tt.n, i, rank, tt.wantWidth)
+			}
+			if i > 0 && ranks[i-1] >= rank {
+				t.Fatalf("RankSequence(%d) not strictly increasing at %d: %q >= %q", tt.n, i, ranks[i-1], rank)
+			}
+		}
+
+		// Harus masih ada ruang sebelum rank pertama dan setelah rank terakhir.
+		if tt.n > 0 {
+			if _, err := RankBetween("", ranks[0]); err != nil {
+				t.Errorf("RankSequence(%d): no room before %q: %v", tt.n, ranks[0], err)
+			}
+			if _, err := RankBetween(ranks[tt.n-1], ""); err != nil {
+				t.Errorf("RankSequence(%d): no room after %q: %v", tt.n, ranks[tt.n-1], err)
+			}
+		}
+	}
+}
+
+func TestRankSequenceSingle(t *testing.T) {
+	if got := RankSequence(1); len(got) != 1 || got[0] != "i" {
+		t.Errorf("RankSequence(1) = %q, want [\"i\"]", got)
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern menangkap "@handle". Handle boleh berisi huruf, angka, titik, garis bawah, dan strip.
+// (?:^|[^\w@]) memastikan "@" bukan bagian dari alamat email seperti "raka@mail.com".
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\p{L}\p{N}._-]+)`)
+
+// ParseMentions mengambil semua handle unik dari teks, misal "halo @Raka dan @budi.s"
+// menghasilkan ["raka", "budi.s"]. Handle dikembalikan dalam huruf kecil.
+func ParseMentions(text string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(text, -1)
+
+	seen := make(map[string]bool, len(matches))
+	handles := make([]string, 0, len(matches))
+	for _, match := range matches {
+		// Titik/strip di akhir biasanya tanda baca ("makasih @raka."), bukan bagian handle.
+		handle := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if handle == "" || seen[handle] {
+			continue
+		}
+		seen[handle] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// MentionHandles mengembalikan handle yang dianggap cocok untuk seorang user:
+// nama tanpa spasi ("Raka Fajar" -> "rakafajar") dan bagian depan email ("raka" dari "raka@mail.com").
+func MentionHandles(name, email string) []string {
+	handles := []string{strings.ToLower(strings.Join(strings.Fields(name), ""))}
+	if at := strings.Index(email, "@"); at > 0 {
+		handles = append(handles, strings.ToLower(email[:at]))
+	}
+	return handles
+}
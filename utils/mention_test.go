@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"no mentions", "halo semua", []string{}},
+		{"single", "@raka tolong cek", []string{"raka"}},
+		{"lowercased", "halo @Raka", []string{"raka"}},
+		{"dots, underscores and dashes", "cc @budi.s @ani_w @joko-p", []string{"budi.s", "ani_w", "joko-p"}},
+		{"trailing punctuation", "makasih @raka. dan @budi-", []string{"raka", "budi"}},
+		{"duplicates are removed", "@raka @RAKA @raka", []string{"raka"}},
+		{"email is not a mention", "kirim ke raka@mail.com", []string{}},
+		{"double at is not a mention", "@@raka", []string{}},
+		{"after punctuation", "(@raka), [@budi]", []string{"raka", "budi"}},
+		{"unicode letters", "halo @José", []string{"josé"}},
+		{"lone at", "@ saja", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseMentions(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMentions(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentionHandles(t *testing.T) {
+	tests := []struct {
+		name, email string
+		want        []string
+	}{
+		{"Raka Fajar", "raka@mail.com", []string{"rakafajar", "raka"}},
+		{"  Budi   Santoso ", "Budi.S@Mail.com", []string{"budisantoso", "budi.s"}},
+		{"Ani", "invalid-email", []string{"ani"}},
+		{"Joko", "@mail.com", []string{"joko"}},
+	}
+
+	for _, tt := range tests {
+		if got := MentionHandles(tt.name, tt.email); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MentionHandles(%q, %q) = %q, want %q", tt.name, tt.email, got, tt.want)
+		}
+	}
+}
@@ -32,3 +32,15 @@ func HashPassword(password string) (string, error) {
 	// Ubah hasil hash (byte array) kembali ke string, lalu kembalikan.
 	return string(bytes), err
 }
+
+// CheckPasswordHash membandingkan password plain text dengan hash bcrypt dari database.
+//
+// KENAPA TIDAK DI-HASH LALU DIBANDINGKAN DENGAN "=="?
+// - Karena bcrypt memakai salt acak, hash dari password yang sama akan selalu berbeda.
+// - bcrypt.CompareHashAndPassword membaca salt dari hash lama, lalu meng-hash ulang password dengan salt itu.
+//
+// Return: true jika password cocok dengan hash.
+func CheckPasswordHash(password, hash string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return err == nil
+}
@@ -2,7 +2,13 @@
 // File ini khusus untuk menstandarisasi format response API
 package utils
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"log"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rakafajars/go-manajemen-project/config"
+)
 
 // =============================================================================
 // STRUCT DEFINITIONS (Definisi Struktur Data)
@@ -23,18 +29,22 @@ import "github.com/gofiber/fiber/v2"
 // Penjelasan field:
 //   - Status: status operasi (Success, Error, Created, dll)
 //   - ResponseCode: HTTP status code (200, 400, 404, 500, dll)
+//   - Code: kode error yang stabil untuk dibaca client, misal "BOARD_NOT_FOUND" (hanya di response error, lihat errors.go)
 //   - Message: pesan yang menjelaskan hasil operasi
 //   - Data: data yang dikembalikan (optional, menggunakan interface{} agar bisa menerima tipe data apapun)
 //   - Error: pesan error jika terjadi kesalahan (optional)
+//   - Details: data tambahan tentang error, misal field yang tidak valid (optional)
 //
 // Tag `json:"..."` digunakan untuk menentukan nama field saat di-serialize ke JSON
 // Tag `omitempty` artinya field tidak akan ditampilkan jika nilainya kosong/nil
 type Response struct {
 	Status       string      `json:"status"`
 	ResponseCode int         `json:"response_code"`
+	Code         ErrorCode   `json:"code,omitempty"`
 	Message      string      `json:"message,omitempty"`
 	Data         interface{} `json:"data,omitempty"`
 	Error        string      `json:"error,omitempty"`
+	Details      interface{} `json:"details,omitempty"`
 }
 
 // ResponsePaginated adalah struktur response untuk data yang menggunakan pagination
@@ -57,6 +67,7 @@ type Response struct {
 type ResponsePaginated struct {
 	Status       string         `json:"status"`
 	ResponseCode int            `json:"response_code"`
+	Code         ErrorCode      `json:"code,omitempty"`
 	Message      string         `json:"message,omitempty"`
 	Data         interface{}    `json:"data,omitempty"`
 	Error        string         `json:"error,omitempty"`
@@ -166,12 +177,7 @@ func Created(c *fiber.Ctx, message string, data interface{}) error {
 //	    }
 //	}
 func BadRequest(c *fiber.Ctx, message string, err string) error {
-	return c.Status(fiber.StatusBadRequest).JSON(Response{
-		Status:       "Error Bad Request",
-		ResponseCode: fiber.StatusBadRequest, // 400
-		Message:      message,
-		Error:        err,
-	})
+	return errorResponse(c, fiber.StatusBadRequest, CodeBadRequest, message, err, nil)
 }
 
 // NotFound mengirim response error dengan HTTP status 404 (Not Found)
@@ -186,12 +192,7 @@ func BadRequest(c *fiber.Ctx, message string, err string) error {
 //	    }
 //	}
 func NotFound(c *fiber.Ctx, message string, err string) error {
-	return c.Status(fiber.StatusNotFound).JSON(Response{
-		Status:       "Error Not Found",
-		ResponseCode: fiber.StatusNotFound, // 404
-		Message:      message,
-		Error:        err,
-	})
+	return errorResponse(c, fiber.StatusNotFound, CodeNotFound, message, err, nil)
 }
 
 // NotFoundPagination mengirim response not found dengan pagination info
@@ -200,6 +201,7 @@ func NotFoundPagination(c *fiber.Ctx, message string, data interface{}, meta Pag
 	return c.Status(fiber.StatusNotFound).JSON(ResponsePaginated{
 		Status:       "Not Found",
 		ResponseCode: fiber.StatusNotFound,
+		Code:         CodeNotFound,
 		Message:      message,
 		Data:         data,
 		Meta:         meta,
@@ -219,12 +221,53 @@ func NotFoundPagination(c *fiber.Ctx, message string, data interface{}, meta Pag
 //	    }
 //	}
 func Unauthorized(c *fiber.Ctx, message string, err string) error {
-	return c.Status(fiber.StatusUnauthorized).JSON(Response{
-		Status:       "Error Unauthorized",
-		ResponseCode: fiber.StatusUnauthorized, // 401
-		Message:      message,
-		Error:        err,
-	})
+	return errorResponse(c, fiber.StatusUnauthorized, CodeUnauthorized, message, err, nil)
+}
+
+// Forbidden mengirim response error dengan HTTP status 403 (Forbidden)
+// Digunakan ketika user sudah login, tapi tidak punya hak akses ke resource tersebut
+// Contoh: user biasa mengakses endpoint admin, user mengubah board milik orang lain
+//
+// Contoh penggunaan:
+//
+//	func DeleteBoard(c *fiber.Ctx) error {
+//	    if board.OwnerID != user.InternalID {
+//	        return utils.Forbidden(c, "Access denied", "Only the owner can delete this board")
+//	    }
+//	}
+func Forbidden(c *fiber.Ctx, message string, err string) error {
+	return errorResponse(c, fiber.StatusForbidden, CodeForbidden, message, err, nil)
+}
+
+// Conflict mengirim response error dengan HTTP status 409 (Conflict)
+// Digunakan ketika data yang dikirim bentrok dengan data yang sudah ada
+// Contoh: email sudah terdaftar, user sudah menjadi member board
+//
+// Contoh penggunaan:
+//
+//	if errors.Is(err, services.ErrEmailAlreadyUsed) {
+//	    return utils.Conflict(c, "Registration failed", err.Error())
+//	}
+func Conflict(c *fiber.Ctx, message string, err string) error {
+	return errorResponse(c, fiber.StatusConflict, CodeConflict, message, err, nil)
+}
+
+// UnprocessableEntity mengirim response error dengan HTTP status 422 (Unprocessable Entity)
+// Digunakan ketika format request benar (JSON valid), tapi isinya tidak lolos validasi
+// details berisi keterangan per field dan boleh nil
+//
+// Contoh penggunaan:
+//
+//	return utils.UnprocessableEntity(c, "Validation failed", map[string]string{"title": "is required"})
+func UnprocessableEntity(c *fiber.Ctx, message string, details interface{}) error {
+	return errorResponse(c, fiber.StatusUnprocessableEntity, CodeValidationFailed, message, "", details)
+}
+
+// TooManyRequests mengirim response error dengan HTTP status 429 (Too Many Requests)
+// Digunakan ketika client mengirim terlalu banyak request dalam waktu singkat (rate limit)
+// Untuk mengirim header Retry-After, gunakan SendError(c, NewRateLimitError(...))
+func TooManyRequests(c *fiber.Ctx, message string, err string) error {
+	return errorResponse(c, fiber.StatusTooManyRequests, CodeRateLimited, message, err, nil)
 }
 
 // InternalServerError mengirim response error dengan HTTP status 500 (Internal Server Error)
@@ -232,7 +275,7 @@ func Unauthorized(c *fiber.Ctx, message string, err string) error {
 // Contoh: database connection error, panic, file system error
 //
 // PENTING: Jangan expose detail error internal ke client di production!
-// Gunakan pesan generic dan log error detail di server
+// Karena itu err selalu dicatat di log server, dan di production tidak ikut dikirim ke client
 //
 // Contoh penggunaan:
 //
@@ -243,10 +286,49 @@ func Unauthorized(c *fiber.Ctx, message string, err string) error {
 //	    }
 //	}
 func InternalServerError(c *fiber.Ctx, message string, err string) error {
-	return c.Status(fiber.StatusInternalServerError).JSON(Response{
-		Status:       "Internal Server Error",
-		ResponseCode: fiber.StatusInternalServerError, // 500
+	return errorResponse(c, fiber.StatusInternalServerError, CodeInternal, message, err, nil)
+}
+
+// ServiceUnavailable mengirim response error dengan HTTP status 503 (Service Unavailable)
+// Digunakan ketika server hidup tapi belum/tidak bisa melayani request
+// Contoh: database tidak bisa dijangkau, migration belum dijalankan (GET /readyz)
+//
+// Berbeda dengan response error lain, data tetap dikirim agar client (misal orchestrator
+// atau tim ops) bisa melihat bagian mana yang bermasalah.
+func ServiceUnavailable(c *fiber.Ctx, message string, data interface{}) error {
+	return c.Status(fiber.StatusServiceUnavailable).JSON(Response{
+		Status:       "Service Unavailable",
+		ResponseCode: fiber.StatusServiceUnavailable, // 503
+		Code:         CodeServiceUnavailable,
+		Message:      message,
+		Data:         data,
+	})
+}
+
+// errorResponse adalah dasar semua response error di atas (dan SendError).
+// Field "status" diisi teks standar HTTP, misal 404 -> "Error Not Found".
+//
+// Untuk error 500, detail error selalu dicatat di log server, dan di production TIDAK
+// dikirim ke client karena bisa berisi informasi internal (query SQL, nama tabel, dll).
+func errorResponse(c *fiber.Ctx, status int, code ErrorCode, message string, err string, details interface{}) error {
+	label := http.StatusText(status)
+	if status < fiber.StatusInternalServerError {
+		label = "Error " + label
+	}
+
+	if status == fiber.StatusInternalServerError {
+		log.Printf("%s %s: %s: %s", c.Method(), c.Path(), message, err)
+		if config.AppConfig != nil && config.AppConfig.IsProduction() {
+			err = ""
+		}
+	}
+
+	return c.Status(status).JSON(Response{
+		Status:       label,
+		ResponseCode: status,
+		Code:         code,
 		Message:      message,
 		Error:        err,
+		Details:      details,
 	})
 }
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+
+	// Decoder format gambar didaftarkan lewat import "kosong" agar image.Decode mengenalinya.
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// ThumbnailContentType adalah MIME type semua thumbnail. JPEG dipilih karena kecil
+	// dan didukung semua browser; gambar transparan diberi latar putih.
+	ThumbnailContentType = "image/jpeg"
+
+	// thumbnailQuality adalah kualitas JPEG thumbnail (1-100).
+	thumbnailQuality = 80
+
+	// maxImagePixels membatasi ukuran gambar yang mau di-decode. Gambar 25 megapixel
+	// butuh sekitar 100 MB memory; di atas itu ditolak agar "decompression bomb"
+	// (file kecil dengan dimensi raksasa) tidak menghabiskan memory server.
+	maxImagePixels = 25_000_000
+)
+
+// ErrImageTooLarge dikembalikan jika dimensi gambar melebihi maxImagePixels.
+var ErrImageTooLarge = errors.New("image dimensions are too large")
+
+// thumbnailableTypes adalah content type gambar yang bisa dibuatkan thumbnail.
+var thumbnailableTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// IsThumbnailable mengecek apakah content type (hasil deteksi isi file) bisa dibuatkan thumbnail.
+func IsThumbnailable(contentType string) bool {
+	return thumbnailableTypes[contentType]
+}
+
+// Thumbnail adalah hasil MakeThumbnail.
+type Thumbnail struct {
+	Data []byte // Isi file thumbnail (JPEG)
+
+	// Width & Height adalah dimensi gambar ASLI, bukan dimensi thumbnail.
+	Width  int
+	Height int
+}
+
+// MakeThumbnail membaca gambar dari r lalu mengecilkannya agar muat di kotak maxSize x maxSize
+// dengan rasio yang tetap. Gambar yang sudah lebih kecil tidak diperbesar.
+func MakeThumbnail(r io.Reader, maxSize int) (*Thumbnail, error) {
+	// Header gambar dibaca dulu (DecodeConfig) untuk mengecek dimensi sebelum seluruh piksel
+	// di-decode. Byte yang sudah dibaca DecodeConfig disalin ke header lalu diputar ulang untuk
+	// Decode, jadi metadata sebesar apa pun (misal EXIF/ICC di JPEG) tetap terbaca.
+	//
+	// Jika header tidak bisa dibaca, gambar ditolak: tanpa dimensi, batas maxImagePixels
+	// tidak bisa dicek sebelum Decode mengalokasikan memory untuk semua piksel.
+	var header bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return nil, err
+	}
+	if tooManyPixels(cfg.Width, cfg.Height) {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if tooManyPixels(width, height) {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, width, height)
+	}
+	thumbWidth, thumbHeight := fitInside(width, height, maxSize)
+
+	dst := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+	return &Thumbnail{Data: buf.Bytes(), Width: width, Height: height}, nil
+}
+
+// tooManyPixels mengecek apakah width x height melebihi maxImagePixels. Setiap sisi dicek
+// dulu agar perkaliannya tidak overflow untuk dimensi raksasa dari header palsu.
+func tooManyPixels(width, height int) bool {
+	if width > maxImagePixels || height > maxImagePixels {
+		return true
+	}
+	return width*height > maxImagePixels
+}
+
+// fitInside menghitung dimensi baru agar width x height muat di kotak maxSize x maxSize.
+func fitInside(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return max(width, 1), max(height, 1)
+	}
+	if width >= height {
+		return maxSize, max(height*maxSize/width, 1)
+	}
+	return max(width*maxSize/height, 1), maxSize
+}
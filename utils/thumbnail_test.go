@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// encodePNG membuat file PNG berukuran width x height.
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withPNGSize mengganti dimensi di chunk IHDR (beserta CRC-nya) tanpa mengubah isi piksel,
+// seperti "decompression bomb" yang mengaku berukuran raksasa.
+func withPNGSize(data []byte, width, height uint32) []byte {
+	out := bytes.Clone(data)
+	// Signature 8 byte, lalu length (4) + "IHDR" (4) + data IHDR (13) + CRC (4).
+	binary.BigEndian.PutUint32(out[16:20], width)
+	binary.BigEndian.PutUint32(out[20:24], height)
+	binary.BigEndian.PutUint32(out[29:33], crc32.ChecksumIEEE(out[12:29]))
+	return out
+}
+
+func TestMakeThumbnail(t *testing.T) {
+	thumb, err := MakeThumbnail(bytes.NewReader(encodePNG(t, 800, 200)), 400)
+	if err != nil {
+		t.Fatalf("MakeThumbnail: %v", err)
+	}
+	if thumb.Width != 800 || thumb.Height != 200 {
+		t.Errorf("original size = %dx%d, want 800x200", thumb.Width, thumb.Height)
+	}
+
+	decoded, err := jpeg.Decode(bytes.NewReader(thumb.Data))
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	if size := decoded.Bounds().Size(); size.X != 400 || size.Y != 100 {
+		t.Errorf("thumbnail size = %dx%d, want 400x100", size.X, size.Y)
+	}
+}
+
+func TestMakeThumbnailRejectsHugeDimensions(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height uint32
+	}{
+		{"over pixel limit", 10_000, 10_000},
+		{"far over pixel limit", 100_000, 100_000},
+		{"one side over pixel limit", 30_000_000, 1},
+	}
+
+	small := encodePNG(t, 2, 2)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MakeThumbnail(bytes.NewReader(withPNGSize(small, tt.width, tt.height)), 400)
+			if !errors.Is(err, ErrImageTooLarge) {
+				t.Errorf("MakeThumbnail error = %v, want ErrImageTooLarge", err)
+			}
+		})
+	}
+}
+
+func TestMakeThumbnailRejectsUnreadableHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"not an image", []byte("definitely not an image")},
+		{"truncated png header", encodePNG(t, 2, 2)[:20]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MakeThumbnail(bytes.NewReader(tt.data), 400); err == nil {
+				t.Error("MakeThumbnail returned no error")
+			}
+		})
+	}
+}
+
+func TestFitInside(t *testing.T) {
+	tests := []struct {
+		width, height, maxSize int
+		wantW, wantH           int
+	}{
+		{100, 50, 400, 100, 50},   // Sudah muat: tidak diperbesar
+		{400, 400, 400, 400, 400}, // Tepat di batas
+		{800, 200, 400, 400, 100}, // Landscape
+		{200, 800, 400, 100, 400}, // Portrait
+		{1000, 1000, 400, 400, 400},
+		{10000, 1, 400, 400, 1}, // Sisi pendek minimal 1 pixel
+		{1, 10000, 400, 1, 400},
+		{0, 0, 400, 1, 1},
+	}
+
+	for _, tt := range tests {
+		gotW, gotH := fitInside(tt.width, tt.height, tt.maxSize)
+		if gotW != tt.wantW || gotH != tt.wantH {
+			t.Errorf("fitInside(%d, %d, %d) = %dx%d, want %dx%d",
+				tt.width, tt.height, tt.maxSize, gotW, gotH, tt.wantW, tt.wantH)
+		}
+	}
+}